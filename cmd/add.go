@@ -36,8 +36,11 @@ import (
 	"khronos/constants"
 	"log"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/agrison/go-commons-lang/stringUtils"
 	"github.com/dromara/carbon/v2"
@@ -45,7 +48,6 @@ import (
 	"github.com/ijt/go-anytime"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 
 	"khronos/internal/database"
 	"khronos/internal/models"
@@ -53,8 +55,8 @@ import (
 
 // addCmd represents the add command
 var addCmd = &cobra.Command{
-	Use:   "add [project+task...]",
-	Args:  cobra.MaximumNArgs(1),
+	Use:   "add [project+task [@time] [TICKET-123] [#tag...] [note...]]",
+	Args:  cobra.ArbitraryArgs,
 	Short: constants.ADD_SHORT_DESCRIPTION,
 	Long:  constants.ADD_LONG_DESCRIPTION,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -70,28 +72,15 @@ func getFavorite(index int) Favorite {
 		os.Exit(1)
 	}
 
-	data, err := os.ReadFile(viper.ConfigFileUsed())
-	if err != nil {
-		log.Fatalf("%s: Error reading configuration file[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), viper.ConfigFileUsed(), err.Error())
-		os.Exit(1)
-	}
-
-	var config Configuration
-
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
-		log.Fatalf("%s: Error unmarshalling configuration file[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), viper.ConfigFileUsed(), err.Error())
-		os.Exit(1)
-	}
-
-	if index >= len(config.Favorites) {
+	var favs []Favorite = loadFavorites()
+	if index >= len(favs) {
 		// index is 0-based internally; report it 1-based to match what the user
 		// typed on the --favorite flag.
 		log.Fatalf("%s: Favorite[%d] not found in configuration file[%s].\n", color.RedString(constants.FATAL_NORMAL_CASE), index+1, viper.ConfigFileUsed())
 		os.Exit(1)
 	}
 
-	return config.Favorites[index]
+	return favs[index]
 }
 
 func init() {
@@ -99,10 +88,27 @@ func init() {
 	addCmd.Flags().StringVarP(&at, constants.AT, constants.EMPTY, constants.EMPTY, constants.NATURAL_LANGUAGE_DESCRIPTION)
 	addCmd.Flags().StringVarP(&note, constants.NOTE, constants.EMPTY, constants.EMPTY, constants.NOTE_DESCRIPTION)
 	addCmd.Flags().IntVarP(&favorite, constants.FAVORITE, constants.EMPTY, -999, "Use the specified Favorite")
+	addCmd.Flags().StringP(constants.FLAG_FOR, constants.EMPTY, constants.EMPTY, constants.FLAG_FOR_DESCRIPTION)
+	addCmd.Flags().StringP(constants.FLAG_SINCE, constants.EMPTY, constants.EMPTY, constants.FLAG_SINCE_DESCRIPTION)
+	addCmd.Flags().StringP(constants.FLAG_GAP, constants.EMPTY, "break", constants.FLAG_GAP_DESCRIPTION)
+	addCmd.Flags().BoolP(constants.FLAG_FORCE, constants.EMPTY, false, constants.FLAG_FORCE_DESCRIPTION)
+	addCmd.Flags().StringP(constants.FLAG_FROM_FILE, constants.EMPTY, constants.EMPTY, constants.FLAG_FROM_FILE_DESCRIPTION)
+	addCmd.Flags().BoolP(constants.FLAG_TICKET_FROM_BRANCH, constants.EMPTY, false, constants.FLAG_TICKET_FROM_BRANCH_DESCRIPTION)
+	addCmd.MarkFlagsMutuallyExclusive(constants.FLAG_FOR, constants.FLAG_SINCE)
+	for _, name := range []string{constants.AT, constants.FAVORITE, constants.FLAG_FOR, constants.FLAG_SINCE} {
+		addCmd.MarkFlagsMutuallyExclusive(constants.FLAG_FROM_FILE, name)
+	}
 	rootCmd.AddCommand(addCmd)
 }
 
 func runAdd(cmd *cobra.Command, args []string) {
+	// A time log file is added as a batch.
+	fromFile, _ := cmd.Flags().GetString(constants.FLAG_FROM_FILE)
+	if !stringUtils.IsEmpty(fromFile) {
+		runAddFromFile(cmd, args, fromFile)
+		return
+	}
+
 	// Get the current date/time.
 	var addTime carbon.Carbon = *carbon.Now()
 
@@ -141,33 +147,84 @@ func runAdd(cmd *cobra.Command, args []string) {
 	var description string = constants.EMPTY
 	var ticket string = constants.EMPTY
 	var requiredNote bool = false
+	var tags []string
+
+	// The favorite being added, if any, supplies the defaults for the entry.
+	var fav Favorite
+	var fromFavorite bool = false
+
+	// The workspace rule matching the current directory, if any, supplies a
+	// default project+task and narrows the favorites.
+	ws, inWorkspace := currentWorkspace()
 
 	favorite, _ := cmd.Flags().GetInt(constants.FAVORITE)
 
 	if favorite != -999 {
 		// The --favorite flag is 1-based for the user (matching the displayed
 		// "#" column); getFavorite indexes 0-based, so convert here.
-		var fav Favorite = getFavorite(favorite - 1)
+		fav = getFavorite(favorite - 1)
+		fromFavorite = true
 		projectTask = fav.Favorite
-        description = fav.Description
-		ticket = fav.Ticket
-		requiredNote = fav.RequireNote
 	} else {
 		if len(args) > 0 {
-			projectTask = args[0]
+			// A favorite's alias may be given in place of its project+task.
+			var words []string = quickAddWords(args)
+			if len(words) > 0 {
+				if aliased, found := findFavoriteByAlias(words[0]); found {
+					fav = aliased
+					fromFavorite = true
+					words[0] = fav.Favorite
+				}
+			}
+
+			if inWorkspace && len(words) > 0 && !fromFavorite {
+				words = applyWorkspaceProjectTask(ws, words)
+			}
+
+			// The arguments may be a quick add, e.g.,
+			// 'acme+review @10:45 ABC-123 #meeting reviewed the auth PR'.
+			qa, err := parseQuickAdd(words, time.Now())
+			if err != nil {
+				log.Fatalf("%s: Unable to parse quick add.  %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+				os.Exit(1)
+			}
+
+			projectTask = qa.ProjectTask
+			ticket = qa.Ticket
+			tags = qa.Tags
+			applyQuickAdd(cmd, qa, &addTime)
 		} else {
 			// Since no parameters were specified, do an interactive add using
-			// the bubbles/table selector. The selector displays the favorites
-			// and returns the chosen index directly, replacing the old
-			// show-then-prompt-for-a-number loop.
+			// the bubbles/table selector. The selector displays the favorites,
+			// followed by the recently used project+tasks, and returns the
+			// chosen one directly, replacing the old show-then-prompt-for-a-
+			// number loop.
 			favs := loadFavorites()
-			if len(favs) <= 0 {
+			var history []projectTaskSummary = projectTaskHistory(db, favoriteUsageDays)
+			var recent []Favorite = recentFavorites(history, favs, viper.GetInt(constants.FAVORITE_RECENT))
+			if len(favs) <= 0 && len(recent) <= 0 {
 				log.Fatalf("%s: No favorites found in configuration file[%s].  Unable to perform an interactive add.\n",
 					color.RedString(constants.FATAL_NORMAL_CASE), viper.ConfigFileUsed())
 				os.Exit(1)
 			}
 
-			idx, ok, err := selectFavorite("Select a favorite to add", viper.ConfigFileUsed(), favs)
+			var smart bool = strings.EqualFold(viper.GetString(constants.FAVORITE_ORDER), constants.FAVORITE_ORDER_SMART)
+
+			// In a workspace, start with its favorites, with the cursor on the
+			// one used most recently.
+			var scope *favoriteScope
+			var preselect string
+			if inWorkspace {
+				scope = &favoriteScope{name: ws.Project, inScope: ws.inScope}
+				for _, summary := range history {
+					if ws.inScope(Favorite{Favorite: summary.ProjectTask}) {
+						preselect = summary.ProjectTask
+						break
+					}
+				}
+			}
+
+			selected, ok, err := selectFavorite("Select a favorite to add", viper.ConfigFileUsed(), favs, recent, favoriteUsage(history), smart, scope, preselect)
 			if err != nil {
 				log.Fatalf("%s: Error running favorites selector. %s\n",
 					color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
@@ -180,21 +237,45 @@ func runAdd(cmd *cobra.Command, args []string) {
 				os.Exit(0)
 			}
 
-			var fav Favorite = getFavorite(idx)
+			fav = selected
+			fromFavorite = true
 			projectTask = fav.Favorite
-            description = fav.Description
+		}
+	}
+
+	// The ticket may come from the current git branch, when neither the quick
+	// add nor the favorite has one.
+	if stringUtils.IsBlank(ticket) && stringUtils.IsBlank(fav.Ticket) {
+		ticket = ticketFromBranch(cmd, ws, inWorkspace)
+	}
+
+	// Apply the favorite's settings and defaults.  Anything given on the
+	// command line wins over the defaults.
+	if fromFavorite {
+		description = fav.Description
+		requiredNote = fav.RequireNote
+		tags = append(append([]string{}, fav.Tags...), tags...)
+
+		if stringUtils.IsBlank(ticket) {
 			ticket = fav.Ticket
-			requiredNote = fav.RequireNote
+		}
+
+		if stringUtils.IsEmpty(note) && !stringUtils.IsEmpty(fav.Note) {
+			note = expandNoteTemplate(fav.Note, addTime, ticket)
 		}
 	}
 
 	// Split the project/task into pieces.
-	var pieces []string = strings.Split(projectTask, constants.TASK_DELIMITER)
-	if len(pieces) < 2 {
-		log.Fatalf("%s: Unable to parsing 'project+task'.  Malformed project+task.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+	project, tasks, err := parseProjectTask(projectTask)
+	if err != nil {
+		log.Fatalf("%s: Unable to parsing 'project+task'.  %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
 		os.Exit(1)
 	}
 
+	if inWorkspace {
+		checkWorkspaceTicket(ws, project, ticket)
+	}
+
 	// Check if the note was empty and the require_note flag is globally set or
 	// set on the favorite.  If so, require the note.
 	if stringUtils.IsEmpty(note) {
@@ -210,13 +291,32 @@ func runAdd(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// The ticket may be mentioned in the note, when nothing else gave one.
+	if stringUtils.IsBlank(ticket) {
+		ticket = ticketFromNote(note, ws, inWorkspace)
+	}
+
 	// Create a new Entry.
-	var entry models.Entry = models.NewEntry(constants.UNKNOWN_UID, pieces[0], note,
+	var entry models.Entry = models.NewEntry(constants.UNKNOWN_UID, project, note,
 		addTime.ToIso8601String(carbon.UTC))
 
 	// Populate the newly created Entry with its tasks.
-	for i := 1; i < len(pieces); i += 1 {
-		entry.AddEntryProperty(constants.TASK, pieces[i])
+	for _, task := range tasks {
+		entry.AddEntryProperty(constants.TASK, task)
+	}
+
+	for _, tag := range tags {
+		entry.AddEntryProperty(constants.TAG, tag)
+	}
+
+	// Add any default properties from the favorite, in a stable order.
+	var propertyNames []string = make([]string, 0, len(fav.Properties))
+	for name := range fav.Properties {
+		propertyNames = append(propertyNames, name)
+	}
+	sort.Strings(propertyNames)
+	for _, name := range propertyNames {
+		entry.AddEntryProperty(name, fav.Properties[name])
 	}
 
 	// If a Ticket was configured for this project+task, add it to the entry.
@@ -225,12 +325,34 @@ func runAdd(cmd *cobra.Command, args []string) {
 		entry.AddEntryProperty(constants.PUSHED, constants.EMPTY)
 	}
 
+	// Classify the entry with the rules in the configuration.
+	entry = applyRulesOnAdd(entry)
+
+	// If the --for or --since flag was entered, work out when the entry started
+	// and whether a gap needs to be filled before it.
+	gapEntry, hasGap := applyStartTime(cmd, db, &entry, addTime, fav.Duration)
+
+	// Make sure the entry fits in the timeline.
+	if !validateTimeline(cmd, db, entry) {
+		log.Printf("%s\n", color.YellowString("Nothing added."))
+		os.Exit(0)
+	}
+
 	// Prompt the user to make sure they really want to add this new entry.
 	log.Printf("You are about to add this entry\n%s...\n\n", entry.Dump(true, constants.INDENT_AMOUNT))
+	if hasGap {
+		log.Printf("The gap since the previous entry will be filled with\n%s...\n\n", gapEntry.Dump(true, constants.INDENT_AMOUNT))
+	}
+
 	yesNo := yesNoPrompt("Continue?")
 	if yesNo {
-		// Yes, they want the entry added. Write the new Entry to the database.
-		db.InsertNewEntry(entry)
+		// Yes, they want the entry added. Write the new Entry, along with any
+		// gap filling entry, to the database.
+		if hasGap {
+			db.InsertNewEntries([]models.Entry{gapEntry, entry})
+		} else {
+			db.InsertNewEntry(entry)
+		}
 		log.Printf("%s.\n", color.GreenString("Entry added"))
 	} else {
 		// No, they do not want the entry added.
@@ -238,6 +360,348 @@ func runAdd(cmd *cobra.Command, args []string) {
 	}
 }
 
+// applyQuickAdd applies the time and note of a quick add, refusing them if
+// they were also given with --at or --note, and echoes how each piece of the
+// quick add was interpreted.
+func applyQuickAdd(cmd *cobra.Command, qa quickAdd, addTime *carbon.Carbon) {
+	if qa.HasTime {
+		if cmd.Flags().Changed(constants.AT) {
+			log.Fatalf("%s: The time was given both in the quick add and with --%s.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.AT)
+			os.Exit(1)
+		}
+
+		*addTime = *carbon.CreateFromStdTime(qa.Time)
+	}
+
+	if !stringUtils.IsEmpty(qa.Note) {
+		if cmd.Flags().Changed(constants.NOTE) {
+			log.Fatalf("%s: The note was given both in the quick add and with --%s.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.NOTE)
+			os.Exit(1)
+		}
+
+		note = qa.Note
+	}
+
+	// A bare project+task needs no explanation.
+	if len(qa.Tokens) > 1 {
+		log.Printf("Quick add interpreted as\n\n%s\n\n", renderQuickAdd(qa))
+	}
+}
+
+// applyWorkspaceProjectTask fills in the project+task of a quick add from the
+// workspace: '+task' gets the workspace's project, and a quick add without a
+// project+task gets its default project+task.
+func applyWorkspaceProjectTask(ws workspaceMatch, words []string) []string {
+	if ws.Project == constants.EMPTY {
+		return words
+	}
+
+	if strings.HasPrefix(words[0], constants.TASK_DELIMITER) {
+		words[0] = ws.Project + words[0]
+	} else if !strings.Contains(words[0], constants.TASK_DELIMITER) && ws.ProjectTask() != constants.EMPTY {
+		words = append([]string{ws.ProjectTask()}, words...)
+	} else {
+		return words
+	}
+
+	log.Printf("%s: Using %s from workspace[%d], matched by %s.\n",
+		color.HiBlueString(constants.INFO_NORMAL_CASE), words[0], ws.Number, ws.Reason)
+	return words
+}
+
+// ticketFromBranch returns the ticket in the current git branch's name, if
+// asked for by --ticket-from-branch or the ticket_from_branch setting.  The
+// ticket is found with the workspace's ticket_pattern, if it has one, or the
+// ticket_branch_pattern setting.  Only when asked for on the command line is
+// a branch without a ticket warned about.
+func ticketFromBranch(cmd *cobra.Command, ws workspaceMatch, inWorkspace bool) string {
+	var explicit bool = cmd.Flags().Changed(constants.FLAG_TICKET_FROM_BRANCH)
+	enabled, _ := cmd.Flags().GetBool(constants.FLAG_TICKET_FROM_BRANCH)
+	if !explicit {
+		enabled = viper.GetBool(constants.TICKET_FROM_BRANCH)
+	}
+
+	if !enabled {
+		return constants.EMPTY
+	}
+
+	var branch string
+	var onBranch bool
+	dir, err := os.Getwd()
+	if err == nil {
+		if gitDir, found := findGitDir(dir); found {
+			branch, onBranch = gitBranch(gitDir)
+		}
+	}
+
+	if !onBranch {
+		if explicit {
+			log.Printf("%s: Not on a git branch, no ticket added from it.\n", color.YellowString("Warning"))
+		}
+		return constants.EMPTY
+	}
+
+	var pattern string = viper.GetString(constants.TICKET_BRANCH_PATTERN)
+	if inWorkspace && ws.TicketPattern != constants.EMPTY {
+		pattern = ws.TicketPattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		log.Fatalf("%s: Invalid ticket pattern[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), pattern, err.Error())
+		os.Exit(1)
+	}
+
+	ticket, found := branchTicket(branch, re)
+	if !found {
+		if explicit {
+			log.Printf("%s: No ticket matching[%s] found in branch[%s].\n", color.YellowString("Warning"), pattern, branch)
+		}
+		return constants.EMPTY
+	}
+
+	log.Printf("%s: Ticket[%s] found in branch[%s].\n", color.HiBlueString(constants.INFO_NORMAL_CASE), ticket, branch)
+	return ticket
+}
+
+// ticketFromNote returns the first ticket mentioned in the note, if asked for
+// by the ticket_from_note setting.  Tickets are found with the workspace's
+// ticket_pattern, if it has one, or the ticket_note_pattern setting, and must
+// be from one of the ticket_note_projects, if any are listed.
+func ticketFromNote(note string, ws workspaceMatch, inWorkspace bool) string {
+	if !viper.GetBool(constants.TICKET_FROM_NOTE) || stringUtils.IsBlank(note) {
+		return constants.EMPTY
+	}
+
+	var pattern string = viper.GetString(constants.TICKET_NOTE_PATTERN)
+	if inWorkspace && ws.TicketPattern != constants.EMPTY {
+		pattern = ws.TicketPattern
+	}
+
+	ticket, found := noteTicket(note, noteTicketRegex(pattern), noteTicketProjects())
+	if !found {
+		return constants.EMPTY
+	}
+
+	log.Printf("%s: Ticket[%s] found in the note.\n", color.HiBlueString(constants.INFO_NORMAL_CASE), ticket)
+	return ticket
+}
+
+// noteTicket returns the first ticket in the note matching the pattern, or
+// the pattern's group if it has one, that is from one of the Jira projects.
+// With no projects, a ticket may be from any.
+func noteTicket(note string, pattern *regexp.Regexp, projects map[string]bool) (string, bool) {
+	for _, match := range pattern.FindAllStringSubmatch(note, -1) {
+		var ticket string = match[0]
+		if len(match) > 1 {
+			ticket = match[1]
+		}
+
+		key, _, _ := strings.Cut(ticket, "-")
+		if ticket != constants.EMPTY && (len(projects) == 0 || projects[strings.ToUpper(key)]) {
+			return ticket, true
+		}
+	}
+
+	return constants.EMPTY, false
+}
+
+// noteTicketRegex compiles the pattern finding the tickets in a note.
+func noteTicketRegex(pattern string) *regexp.Regexp {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		log.Fatalf("%s: Invalid ticket pattern[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), pattern, err.Error())
+		os.Exit(1)
+	}
+
+	return re
+}
+
+// noteTicketProjects returns the Jira project keys of the ticket_note_projects
+// setting.
+func noteTicketProjects() map[string]bool {
+	keys, err := parseTicketProjects(viper.GetString(constants.TICKET_NOTE_PROJECTS))
+	if err != nil {
+		log.Fatalf("%s: %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	var projects map[string]bool = make(map[string]bool)
+	for _, key := range keys {
+		projects[key] = true
+	}
+
+	return projects
+}
+
+// parseTicketProjects splits a list of Jira project keys, separated by commas
+// or spaces, e.g., "ABC, ACME", upper casing them.
+func parseTicketProjects(value string) ([]string, error) {
+	var keys []string
+	for _, key := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		key = strings.ToUpper(key)
+		if !ticketProjectRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid %s[%s], must be Jira project keys such as ABC, ACME", constants.TICKET_NOTE_PROJECTS, value)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// checkWorkspaceTicket warns when the ticket of an entry for the workspace's
+// project does not match its ticket pattern, e.g., a ticket from another
+// Jira project.
+func checkWorkspaceTicket(ws workspaceMatch, project string, ticket string) {
+	if stringUtils.IsBlank(ticket) || !strings.EqualFold(project, ws.Project) {
+		return
+	}
+
+	re, err := ws.ticketRegex()
+	if err != nil {
+		log.Printf("%s: Workspace[%d] has an %s.\n", color.YellowString("Warning"), ws.Number, err.Error())
+	} else if re != nil && !re.MatchString(ticket) {
+		log.Printf("%s: Ticket[%s] does not match the ticket pattern[%s] of workspace[%d].\n",
+			color.YellowString("Warning"), ticket, ws.TicketPattern, ws.Number)
+	}
+}
+
+// findFavoriteByAlias returns the favorite with the given alias, if any.
+// Aliases are matched without regard to case.
+func findFavoriteByAlias(alias string) (Favorite, bool) {
+	for _, f := range loadFavorites() {
+		if !stringUtils.IsEmpty(f.Alias) && strings.EqualFold(f.Alias, alias) {
+			return f, true
+		}
+	}
+
+	return Favorite{}, false
+}
+
+// expandNoteTemplate fills in the placeholders of a favorite's default note:
+// {date}, {weekday}, and {ticket}.
+func expandNoteTemplate(template string, addTime carbon.Carbon, ticket string) string {
+	var local carbon.Carbon = *addTime.Copy().SetTimezone(carbon.Local)
+
+	return strings.NewReplacer(
+		"{date}", local.ToDateString(),
+		"{weekday}", local.ToWeekString(),
+		"{ticket}", ticket,
+	).Replace(template)
+}
+
+// parseProjectTask splits a 'project+task[+task...]' string into its project
+// and one or more tasks.
+func parseProjectTask(projectTask string) (string, []string, error) {
+	var pieces []string = strings.Split(projectTask, constants.TASK_DELIMITER)
+	if len(pieces) < 2 {
+		return constants.EMPTY, nil, fmt.Errorf("malformed project+task[%s]", projectTask)
+	}
+
+	for _, piece := range pieces {
+		if stringUtils.IsBlank(piece) {
+			return constants.EMPTY, nil, fmt.Errorf("malformed project+task[%s], empty project or task", projectTask)
+		}
+	}
+
+	return pieces[0], pieces[1:], nil
+}
+
+// applyStartTime looks at the --for and --since flags and, if either was
+// entered, sets the entry's duration so it starts at the requested time.  If the
+// previous entry ended before that start time, a break or untracked entry is
+// returned to fill the gap; if it ended after the start time, the add is
+// rejected since the two entries would overlap.  defaultFor, typically a
+// favorite's default duration, is used when neither flag was entered.
+func applyStartTime(cmd *cobra.Command, db *database.Database, entry *models.Entry, addTime carbon.Carbon, defaultFor string) (models.Entry, bool) {
+	forStr, _ := cmd.Flags().GetString(constants.FLAG_FOR)
+	sinceStr, _ := cmd.Flags().GetString(constants.FLAG_SINCE)
+	gap, _ := cmd.Flags().GetString(constants.FLAG_GAP)
+
+	var usingDefault bool = false
+	if stringUtils.IsEmpty(forStr) && stringUtils.IsEmpty(sinceStr) {
+		forStr = defaultFor
+		usingDefault = !stringUtils.IsEmpty(defaultFor)
+	}
+
+	var none models.Entry = models.NewEntry(constants.UNKNOWN_UID, constants.EMPTY, constants.EMPTY, constants.EMPTY)
+	var startTime carbon.Carbon
+
+	if !stringUtils.IsEmpty(forStr) {
+		duration, err := time.ParseDuration(forStr)
+		if err != nil || duration <= 0 {
+			log.Fatalf("%s: Failed parsing 'for' duration[%s].  Please use a positive duration such as '45m' or '1h30m'.\n",
+				color.RedString(constants.FATAL_NORMAL_CASE), forStr)
+			os.Exit(1)
+		}
+
+		startTime = *addTime.Copy().SubSeconds(int(duration.Seconds()))
+	} else if !stringUtils.IsEmpty(sinceStr) {
+		// A time of day is on the entry's day, not today's.
+		sinceTime, err := anytime.Parse(sinceStr, addTime.StdTime().In(time.Local))
+		if err != nil {
+			log.Fatalf("%s: Failed parsing 'since' time. %s.  For natural date examples see https://github.com/ijt/go-anytime\n",
+				color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		startTime = *carbon.CreateFromStdTime(sinceTime)
+	} else {
+		return none, false
+	}
+
+	if !startTime.Lt(&addTime) {
+		log.Fatalf("%s: The start time[%s] must be before the end time[%s].\n", color.RedString(constants.FATAL_NORMAL_CASE),
+			startTime.ToIso8601String(carbon.Local), addTime.ToIso8601String(carbon.Local))
+		os.Exit(1)
+	}
+
+	var gapProject string
+	if strings.EqualFold(gap, "break") {
+		gapProject = constants.BREAK
+	} else if strings.EqualFold(gap, "untracked") {
+		gapProject = constants.UNTRACKED
+	} else {
+		log.Fatalf("%s: Invalid 'gap' value[%s].  Allowed values: \"break\" or \"untracked\".\n", color.RedString(constants.FATAL_NORMAL_CASE), gap)
+		os.Exit(1)
+	}
+
+	entry.Duration = startTime.DiffAbsInSeconds(&addTime)
+
+	// Look at the entry just before this one to see if there is a gap or an
+	// overlap.
+	var previous models.Entry = db.GetEntryBefore(addTime.ToIso8601String(carbon.UTC))
+	if previous.Uid == constants.UNKNOWN_UID {
+		return none, false
+	}
+
+	var previousTime carbon.Carbon = *carbon.Parse(previous.EntryDatetime)
+
+	// A default duration is only a default; rather than rejecting the add,
+	// start the entry where the previous one ended.
+	if usingDefault && previousTime.Gt(&startTime) && previousTime.Lt(&addTime) {
+		log.Printf("%s: The default duration[%s] overlaps the previous entry, starting at %s instead.\n\n",
+			color.HiBlueString(constants.INFO_NORMAL_CASE), defaultFor, previousTime.ToIso8601String(carbon.Local))
+		startTime = previousTime
+		entry.Duration = startTime.DiffAbsInSeconds(&addTime)
+	}
+
+	if previousTime.Gt(&startTime) {
+		log.Fatalf("%s: The previous entry ended at %s, which is after the requested start time of %s.\n",
+			color.RedString(constants.FATAL_NORMAL_CASE), previousTime.ToIso8601String(carbon.Local), startTime.ToIso8601String(carbon.Local))
+		os.Exit(1)
+	}
+
+	if previousTime.Eq(&startTime) {
+		return none, false
+	}
+
+	var gapEntry models.Entry = models.NewEntry(constants.UNKNOWN_UID, gapProject, constants.EMPTY, startTime.ToIso8601String(carbon.UTC))
+	gapEntry.Duration = previousTime.DiffAbsInSeconds(&startTime)
+
+	return gapEntry, true
+}
+
 func promptForNote(projectTask string, description string, required bool) string {
 	var s string
 	var prompt string
@@ -266,6 +730,8 @@ func promptForNote(projectTask string, description string, required bool) string
 
 	prompt += "Enter note or leave blank to quit. > "
 
+	requireInteractive("a note is required; use --note to enter one", constants.EXIT_INPUT_REQUIRED)
+
 	fmt.Print(prompt)
 	s, _ = readLine(stdinReader)
 	s = strings.TrimSpace(s)
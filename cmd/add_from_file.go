@@ -0,0 +1,360 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"khronos/constants"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// logLineRegex matches a time log line: an optional date, a time, what was
+// done, and an optional note.
+var logLineRegex = regexp.MustCompile(`^(?:(\d{4}-\d{2}-\d{2})\s+)?(\d{1,2}:\d{2}(?::\d{2})?(?:\s*[aApP][mM])?)\s+(\S+)(?:\s+(.*))?$`)
+
+// logLineTimeLayouts are the accepted layouts for the time of a log line,
+// after it has been lower cased and had its spaces removed.
+var logLineTimeLayouts = []string{"15:04", "15:04:05", "3:04pm", "3:04:05pm"}
+
+// logLine is a single parsed line of a time log.
+type logLine struct {
+	number int
+	entry  models.Entry
+}
+
+// logLineError is a problem found on a line of a time log.
+type logLineError struct {
+	number  int
+	message string
+}
+
+func newLogLineError(number int, format string, args ...any) logLineError {
+	return logLineError{number, fmt.Sprintf(format, args...)}
+}
+
+func runAddFromFile(cmd *cobra.Command, args []string, fromFile string) {
+	if len(args) > 0 {
+		log.Fatalf("%s: A project+task cannot be given along with --%s.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.FLAG_FROM_FILE)
+		os.Exit(1)
+	}
+
+	var reader io.Reader = os.Stdin
+	if fromFile != "-" {
+		file, err := os.Open(fromFile)
+		if err != nil {
+			log.Fatalf("%s: Error opening time log file[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), fromFile, err.Error())
+			os.Exit(1)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	lines, errs := parseTimeLog(reader)
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	errs = append(errs, validateTimeLog(db, lines)...)
+
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].number < errs[j].number })
+		for _, err := range errs {
+			log.Printf("%s: line %d: %s\n", color.RedString("Error"), err.number, err.message)
+		}
+		log.Fatalf("%s: %d error(s) found in the time log.  Nothing added.\n", color.RedString(constants.FATAL_NORMAL_CASE), len(errs))
+		os.Exit(1)
+	}
+
+	if len(lines) == 0 {
+		log.Printf("%s\n", color.YellowString("No entries found in the time log. Nothing added."))
+		return
+	}
+
+	classifyTimeLog(lines)
+
+	var entries []models.Entry = previewTimeLog(cmd, db, lines)
+
+	yesNo := yesNoPrompt("\nAdd these %d entries?", len(entries))
+	if yesNo {
+		// All the entries are written in a single transaction.
+		db.InsertNewEntries(entries)
+		log.Printf("%s\n", color.GreenString("Entries added."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Nothing added."))
+	}
+}
+
+// parseTimeLog reads every line of a time log, returning the entries along
+// with an error for each line that could not be parsed.  Blank lines and lines
+// starting with '#' are ignored.  A line without a date uses the date of the
+// line before it, or today if no date has been given yet.
+func parseTimeLog(reader io.Reader) ([]logLine, []logLineError) {
+	var lines []logLine
+	var errs []logLineError
+	var date string = carbon.Now().SetTimezone(carbon.Local).ToDateString()
+
+	scanner := bufio.NewScanner(reader)
+	var number int = 0
+	for scanner.Scan() {
+		number++
+
+		var text string = strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		matches := logLineRegex.FindStringSubmatch(text)
+		if matches == nil {
+			errs = append(errs, newLogLineError(number, "expected '[YYYY-MM-DD] HH:MM hello|break|project+task [note]', got [%s]", text))
+			continue
+		}
+
+		if len(matches[1]) > 0 {
+			date = matches[1]
+		}
+
+		entryTime, err := parseLogLineTime(date, matches[2])
+		if err != nil {
+			errs = append(errs, newLogLineError(number, "%s", err.Error()))
+			continue
+		}
+
+		var what string = matches[3]
+		var lineNote string = strings.TrimSpace(matches[4])
+		var datetime string = carbon.CreateFromStdTime(entryTime).ToIso8601String(carbon.UTC)
+
+		var entry models.Entry
+		if strings.EqualFold(what, "hello") || strings.EqualFold(what, constants.HELLO) {
+			if len(lineNote) > 0 {
+				errs = append(errs, newLogLineError(number, "a hello cannot have a note"))
+				continue
+			}
+			entry = models.NewEntry(constants.UNKNOWN_UID, constants.HELLO, constants.EMPTY, datetime)
+		} else if strings.EqualFold(what, "break") || strings.EqualFold(what, constants.BREAK) {
+			entry = models.NewEntry(constants.UNKNOWN_UID, constants.BREAK, lineNote, datetime)
+		} else {
+			project, tasks, err := parseProjectTask(what)
+			if err != nil {
+				errs = append(errs, newLogLineError(number, "%s", err.Error()))
+				continue
+			}
+
+			entry = models.NewEntry(constants.UNKNOWN_UID, project, lineNote, datetime)
+			for _, task := range tasks {
+				entry.AddEntryProperty(constants.TASK, task)
+			}
+		}
+
+		lines = append(lines, logLine{number, entry})
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, newLogLineError(number+1, "%s", err.Error()))
+	}
+
+	return lines, errs
+}
+
+// classifyTimeLog gives the entries of the batch the ticket mentioned in their
+// note, and classifies them with the rules in the configuration, as add does.
+// A hello is left alone, and a break has no ticket.
+func classifyTimeLog(lines []logLine) {
+	ws, inWorkspace := currentWorkspace()
+	var rules []compiledRule = loadRules()
+	for i := range lines {
+		var entry *models.Entry = &lines[i].entry
+		if strings.EqualFold(entry.Project, constants.HELLO) {
+			continue
+		}
+
+		if !isBreakOrUntracked(entry.Project) && stringUtils.IsBlank(entry.GetTicketAsString()) {
+			if ticket := ticketFromNote(entry.Note, ws, inWorkspace); !stringUtils.IsBlank(ticket) {
+				entry.AddEntryProperty(constants.TICKET, ticket)
+				entry.AddEntryProperty(constants.PUSHED, constants.EMPTY)
+			}
+		}
+
+		*entry, _ = applyRules(rules, *entry)
+	}
+}
+
+// parseLogLineTime combines a date and a time from a log line into a local
+// date/time.
+func parseLogLineTime(date string, clock string) (time.Time, error) {
+	clock = strings.ToLower(strings.ReplaceAll(clock, " ", constants.EMPTY))
+
+	for _, layout := range logLineTimeLayouts {
+		t, err := time.ParseInLocation(constants.DATE_FORMAT+" "+layout, date+" "+clock, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date/time[%s %s]", date, clock)
+}
+
+// validateTimeLog checks the batch as a whole: the lines must be in order and
+// not in the future, every day must start with a hello, either in the log or
+// already in the database, and no existing entry may fall within the batch.
+func validateTimeLog(db *database.Database, lines []logLine) []logLineError {
+	var errs []logLineError
+	var now carbon.Carbon = *carbon.Now()
+	var helloDay string = constants.EMPTY
+
+	for i, line := range lines {
+		var entryTime carbon.Carbon = *carbon.Parse(line.entry.EntryDatetime)
+
+		if entryTime.Gt(&now) {
+			errs = append(errs, newLogLineError(line.number, "%s is in the future", entryTime.ToIso8601String(carbon.Local)))
+		}
+
+		if i > 0 {
+			var previousTime carbon.Carbon = *carbon.Parse(lines[i-1].entry.EntryDatetime)
+			if !entryTime.Gt(&previousTime) {
+				errs = append(errs, newLogLineError(line.number, "%s is not after line %d's %s",
+					entryTime.ToIso8601String(carbon.Local), lines[i-1].number, previousTime.ToIso8601String(carbon.Local)))
+			}
+
+			// An existing entry between two lines would be split by the batch.
+			var existing models.Entry = db.GetEntryAfter(lines[i-1].entry.EntryDatetime)
+			if existing.Uid != constants.UNKNOWN_UID {
+				var existingTime carbon.Carbon = *carbon.Parse(existing.EntryDatetime)
+				if existingTime.Eq(&entryTime) {
+					errs = append(errs, newLogLineError(line.number, "the existing %s entry has the same date/time", neighbourName(existing)))
+				} else if existingTime.Lt(&entryTime) {
+					errs = append(errs, newLogLineError(line.number, "the existing %s entry at %s falls between lines %d and %d",
+						neighbourName(existing), existingTime.ToIso8601String(carbon.Local), lines[i-1].number, line.number))
+				}
+			}
+		} else {
+			for _, e := range db.GetEntriesForToday(entryTime, entryTime) {
+				errs = append(errs, newLogLineError(line.number, "the existing %s entry has the same date/time", neighbourName(e)))
+				break
+			}
+		}
+
+		var day string = entryTime.ToDateString()
+		if strings.EqualFold(line.entry.Project, constants.HELLO) {
+			helloDay = day
+			continue
+		}
+
+		if day != helloDay && !hasHelloBefore(db, entryTime) {
+			errs = append(errs, newLogLineError(line.number, "there is no %s before %s", constants.HELLO, entryTime.ToIso8601String(carbon.Local)))
+		}
+	}
+
+	return errs
+}
+
+// hasHelloBefore reports whether the database has a hello on the same day as,
+// and before, the given date/time.
+func hasHelloBefore(db *database.Database, entryTime carbon.Carbon) bool {
+	for _, e := range db.GetEntriesForToday(*entryTime.Copy().StartOfDay(), entryTime) {
+		if strings.EqualFold(e.Project, constants.HELLO) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// previewTimeLog shows every entry of the batch with its duration, followed
+// by the totals and a by project report, and returns the entries.
+func previewTimeLog(cmd *cobra.Command, db *database.Database, lines []logLine) []models.Entry {
+	var entries []models.Entry
+	var reportEntries []models.Entry
+
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{"Line", constants.DATE_TIME_NORMAL_CASE, constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE,
+		constants.NOTE_NORMAL_CASE, "Properties", constants.DURATION_NORMAL_CASE})
+
+	// The first entry's duration starts at the existing entry before it, if any.
+	var previousDatetime string = db.GetEntryBefore(lines[0].entry.EntryDatetime).EntryDatetime
+	for _, line := range lines {
+		var entry models.Entry = line.entry
+
+		// A hello starts the day, so it has no duration.
+		var duration string = "-"
+		if !strings.EqualFold(entry.Project, constants.HELLO) && previousDatetime != constants.EMPTY {
+			var entryTime carbon.Carbon = *carbon.Parse(entry.EntryDatetime)
+			entry.Duration = carbon.Parse(previousDatetime).DiffAbsInSeconds(&entryTime)
+			duration = formatSeconds(entry.Duration)
+		}
+
+		t.AppendRow(table.Row{line.number, carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local), entry.Project,
+			entry.GetTasksAsString(), entry.Note, logLineProperties(entry), duration})
+
+		if !strings.EqualFold(entry.Project, constants.HELLO) {
+			reportEntries = append(reportEntries, entry)
+		}
+
+		entries = append(entries, line.entry)
+		previousDatetime = entry.EntryDatetime
+	}
+
+	log.Printf("You are about to add these entries\n\n%s\n", t.Render())
+
+	// Show the totals and a by project report, unrounded, for the batch.
+	reportTotalWorkAndBreakTime(reportEntries, 0)
+	reportByProject(cmd, reportEntries, 0)
+
+	return entries
+}
+
+// logLineProperties returns the properties of an entry of the batch, other
+// than its tasks and push state, e.g., the tags and ticket the rules gave it.
+func logLineProperties(entry models.Entry) string {
+	var properties []string
+	for _, p := range entry.Properties {
+		if p.Name != constants.TASK && !models.IsPushProperty(p.Name) {
+			properties = append(properties, p.Name+":"+p.Value)
+		}
+	}
+
+	return strings.Join(properties, ", ")
+}
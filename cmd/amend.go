@@ -36,10 +36,11 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/agrison/go-commons-lang/stringUtils"
 	"github.com/dromara/carbon/v2"
 	"github.com/fatih/color"
+	"github.com/ijt/go-anytime"
 	"github.com/jedib0t/go-pretty/v6/table"
 
 	"github.com/spf13/cobra"
@@ -61,74 +62,55 @@ var amendCmd = &cobra.Command{
 }
 
 func init() {
-	amendCmd.Flags().BoolP("today", constants.EMPTY, false, "List all the entries for today.")
+	amendCmd.Flags().BoolP(constants.FLAG_TODAY, constants.EMPTY, false, "List all the entries for today.")
 	amendCmd.Flags().StringVarP(&givenDate, constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "List all the entries for the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
-	amendCmd.MarkFlagsMutuallyExclusive("today", constants.FLAG_DATE)
+	amendCmd.Flags().BoolP(constants.FLAG_FORCE, constants.EMPTY, false, constants.FLAG_FORCE_DESCRIPTION)
+	amendCmd.Flags().Int64P(constants.FLAG_UID, constants.EMPTY, constants.UNKNOWN_UID, "Amend the entry with the given uid.")
+	amendCmd.Flags().StringP(constants.FLAG_PROJECT, constants.EMPTY, constants.EMPTY, "Change the project to the given value without prompting.")
+	amendCmd.Flags().StringP(constants.TASK, constants.EMPTY, constants.EMPTY, "Change the task to the given value without prompting.")
+	amendCmd.Flags().StringP(constants.NOTE, constants.EMPTY, constants.EMPTY, "Change the note to the given value without prompting.")
+	amendCmd.Flags().StringP(constants.TICKET, constants.EMPTY, constants.EMPTY, "Change the ticket to the given value without prompting.  An empty value removes it.")
+	amendCmd.Flags().StringP(constants.AT, constants.EMPTY, constants.EMPTY, "Change the date/time to the given Natural Language Time without prompting.")
+	amendCmd.MarkFlagsMutuallyExclusive(constants.FLAG_TODAY, constants.FLAG_DATE, constants.FLAG_UID)
 	rootCmd.AddCommand(amendCmd)
 }
 
 func runAmend(cmd *cobra.Command, _ []string) {
-	var entry models.Entry
-
-	today, _ := cmd.Flags().GetBool("today")
-	givenDate, _ := cmd.Flags().GetString(constants.FLAG_DATE)
-
 	db := database.New(viper.GetString(constants.DATABASE_FILE))
-	if today || !stringUtils.IsEmpty(givenDate) {
-		var entries []models.Entry
-
-		if today {
-			entries = db.GetEntriesForToday(*carbon.Now().StartOfDay(), *carbon.Now().EndOfDay())
-		} else {
-			entries = db.GetEntriesForToday(*carbon.Parse(givenDate).StartOfDay(), *carbon.Parse(givenDate).EndOfDay())
-		}
 
-		if len(entries) == 0 {
-			log.Printf("%s\n", color.YellowString("No entries found."))
-			return
-		}
+	entry, ok := chooseEntry(cmd, db, "Select an entry to amend")
+	if !ok {
+		log.Printf("%s\n", color.YellowString("No entry amended."))
+		return
+	}
 
-		// Show the entries in an interactive selector and let the user pick one.
-		idx, ok, err := selectEntry("Select an entry to amend", entries)
-		if err != nil {
-			log.Fatalf("%s: Error running entry selector. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
-			os.Exit(1)
-		}
+	log.Printf("%s", "Amending...\n"+entry.Dump(true, constants.INDENT_AMOUNT)+"\n\n")
 
-		if !ok {
-			// User cancelled - nothing to amend.
-			log.Printf("%s\n", color.YellowString("No entry amended."))
-			return
-		}
+	var newProject, newTask, newNote, newTicket, newEntryDatetime string
 
-		entry = entries[idx]
+	if amendFlagsChanged(cmd) {
+		// The fields were given on the command line; only change those.
+		newProject, newTask, newNote, newTicket, newEntryDatetime = amendFromFlags(cmd, entry)
 	} else {
-		// Get the last Entry from the database.
-		entry = db.GetLastEntry()
-	}
-
-	log.Printf("%s", "Amending...\n"+entry.Dump(true, constants.INDENT_AMOUNT)+"\n\n")
+		// Prompt to change project.
+		newProject = prompt(constants.PROJECT_NORMAL_CASE, entry.Project)
 
-	// Prompt to change project.
-	newProject := prompt(constants.PROJECT_NORMAL_CASE, entry.Project)
+		// If we are modifying a break, there is no need to ask for a task since
+		// breaks do not have tasks.
+		if !strings.EqualFold(newProject, constants.BREAK) {
+			newTask = prompt(constants.TASK_NORMAL_CASE, entry.GetTasksAsString())
+		}
 
-	// If we are modifying a break, there is no need to ask for a task since
-	// breaks do not have tasks.
-	var newTask string = constants.EMPTY
-	if !strings.EqualFold(newProject, constants.BREAK) {
-		newTask = prompt(constants.TASK_NORMAL_CASE, entry.GetTasksAsString())
-	}
+		newNote = prompt(constants.NOTE_NORMAL_CASE, entry.Note)
 
-	newNote := prompt(constants.NOTE_NORMAL_CASE, entry.Note)
+		// If there was an TICKET, prompt to change it.
+		if len(entry.GetTicketAsString()) > 0 {
+			newTicket = prompt(constants.TICKET_NORMAL_CASE, entry.GetTicketAsString())
+		}
 
-	// If there was an TICKET, prompt to change it.
-	var newTicket string = constants.EMPTY
-	if len(entry.GetTicketAsString()) > 0 {
-		newTicket = prompt(constants.TICKET_NORMAL_CASE, entry.GetTicketAsString())
+		newEntryDatetime = prompt(constants.DATE_TIME_NORMAL_CASE, carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local))
 	}
 
-	newEntryDatetime := prompt(constants.DATE_TIME_NORMAL_CASE, carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local))
-
 	// Validate that the user entered a correctly formatted date/time.
 	e := carbon.Parse(newEntryDatetime)
 	if e.Error != nil {
@@ -139,6 +121,44 @@ func runAmend(cmd *cobra.Command, _ []string) {
 
 	log.Printf("\n")
 
+	// The worklog of a pushed entry is already under its ticket.
+	var ticketChanged bool = !strings.EqualFold(newTicket, entry.GetTicketAsString())
+	if ticketChanged && entry.IsPushed() {
+		log.Fatalf("%s: The entry was already pushed and its ticket cannot be changed.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+		os.Exit(1)
+	}
+
+	// If the date/time changed, make sure the entry still fits in the timeline.
+	if !carbon.Parse(newEntryDatetime).Eq(carbon.Parse(entry.EntryDatetime)) {
+		var moved models.Entry = models.NewEntry(entry.Uid, newProject, newNote, carbon.Parse(newEntryDatetime).ToIso8601String())
+		moved.Properties = entry.Properties
+		if !validateTimeline(cmd, db, moved) {
+			log.Printf("%s\n", color.YellowString("Entry NOT amended."))
+			return
+		}
+	}
+
+	// Classify the amended entry with the rules in the configuration.  It
+	// keeps its other properties, e.g., its tags.  An entry gains a pushed
+	// property when it gets a ticket, so it can be pushed, and loses it when
+	// its ticket is removed, unless it was already pushed.
+	var amended models.Entry = models.NewEntry(entry.Uid, newProject, newNote, newEntryDatetime)
+	for _, p := range entry.Properties {
+		if p.Name != constants.TASK && p.Name != constants.TICKET && p.Name != constants.PUSHED {
+			amended.AddEntryProperty(p.Name, p.Value)
+		}
+	}
+	if len(newTask) > 0 {
+		amended.AddEntryProperty(constants.TASK, newTask)
+	}
+	if len(newTicket) > 0 {
+		amended.AddEntryProperty(constants.TICKET, newTicket)
+		amended.AddEntryProperty(constants.PUSHED, entry.GetPushedAsString())
+	} else if len(entry.GetPushedAsString()) > 0 {
+		amended.AddEntryProperty(constants.PUSHED, entry.GetPushedAsString())
+	}
+	ruled, applied := applyRules(loadRules(), amended)
+
 	// Create a table to show the old verses new values.
 	var t table.Writer = table.NewWriter()
 	t.Style().Options.DrawBorder = false
@@ -147,7 +167,7 @@ func runAmend(cmd *cobra.Command, _ []string) {
 	t.AppendRow(table.Row{constants.TASK_NORMAL_CASE, entry.GetTasksAsString(), newTask})
 	t.AppendRow(table.Row{constants.NOTE_NORMAL_CASE, entry.Note, newNote})
 
-	if len(newTicket) > 0 {
+	if len(newTicket) > 0 || ticketChanged {
 		t.AppendRow(table.Row{constants.TICKET_NORMAL_CASE, entry.GetTicketAsString(), newTicket})
 	}
 
@@ -155,6 +175,10 @@ func runAmend(cmd *cobra.Command, _ []string) {
 		carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local),
 		carbon.Parse(newEntryDatetime).ToIso8601String(carbon.Local)})
 
+	if len(applied) > 0 {
+		t.AppendRow(table.Row{"Rules", constants.EMPTY, strings.Join(applied, ", ") + "\n" + strings.Join(propertyChanges(amended, ruled), ", ")})
+	}
+
 	// Render the table.
 	log.Println(t.Render())
 
@@ -173,6 +197,9 @@ func runAmend(cmd *cobra.Command, _ []string) {
 		}
 
 		db.UpdateEntry(e)
+		if len(applied) > 0 || ticketChanged {
+			db.UpdateEntriesProperties([]models.Entry{ruled})
+		}
 
 		log.Printf("%s\n", color.GreenString("Entry amended."))
 	} else {
@@ -180,7 +207,68 @@ func runAmend(cmd *cobra.Command, _ []string) {
 	}
 }
 
+// amendFlagsChanged reports whether any of the fields to amend were given on
+// the command line.
+func amendFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{constants.FLAG_PROJECT, constants.TASK, constants.NOTE, constants.TICKET, constants.AT} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// amendFromFlags returns the amended project, task, note, ticket, and
+// date/time, taking each from its flag if given and from the entry otherwise.
+func amendFromFlags(cmd *cobra.Command, entry models.Entry) (string, string, string, string, string) {
+	var newProject string = entry.Project
+	var newTask string = entry.GetTasksAsString()
+	var newNote string = entry.Note
+	var newTicket string = entry.GetTicketAsString()
+	var newEntryDatetime string = carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local)
+
+	if cmd.Flags().Changed(constants.FLAG_PROJECT) {
+		newProject, _ = cmd.Flags().GetString(constants.FLAG_PROJECT)
+	}
+
+	if cmd.Flags().Changed(constants.TASK) {
+		newTask, _ = cmd.Flags().GetString(constants.TASK)
+	}
+
+	if cmd.Flags().Changed(constants.NOTE) {
+		newNote, _ = cmd.Flags().GetString(constants.NOTE)
+	}
+
+	if cmd.Flags().Changed(constants.TICKET) {
+		newTicket, _ = cmd.Flags().GetString(constants.TICKET)
+		newTicket = strings.TrimSpace(newTicket)
+	}
+
+	if cmd.Flags().Changed(constants.AT) {
+		atTimeStr, _ := cmd.Flags().GetString(constants.AT)
+		atTime, err := anytime.Parse(atTimeStr, time.Now())
+		if err != nil {
+			log.Fatalf("%s: Failed parsing 'at' time. %s.  For natural date examples see https://github.com/ijt/go-anytime\n",
+				color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		newEntryDatetime = carbon.CreateFromStdTime(atTime).ToIso8601String(carbon.Local)
+	}
+
+	// Breaks do not have tasks or tickets.
+	if strings.EqualFold(newProject, constants.BREAK) {
+		newTask = constants.EMPTY
+		newTicket = constants.EMPTY
+	}
+
+	return newProject, newTask, newNote, newTicket, newEntryDatetime
+}
+
 func prompt(label string, value string) string {
+	requireInteractive("entering the "+label+" is required", constants.EXIT_INPUT_REQUIRED)
+
 	fmt.Fprintf(os.Stderr, "Enter %s (empty for no change) ["+value+"] > ", label)
     s, _ := readLine(stdinReader)
     s = strings.TrimSpace(s)
@@ -31,27 +31,52 @@ POSSIBILITY OF SUCH DAMAGE.
 package cmd
 
 import (
+	"log"
+	"os"
 	"strconv"
 	"strings"
 
 	"khronos/constants"
+	"khronos/internal/database"
 	"khronos/internal/models"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/agrison/go-commons-lang/stringUtils"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
 )
 
 // entrySelectorModel renders a list of entries as a fully-bordered go-pretty
-// table and lets the user move a cursor, jump to a row by number, and select.
-// It mirrors favoriteSelectorModel; the difference is the columns rendered and
-// that there is no config path in the help line.
+// table and lets the user move a cursor, jump to a row by number, filter, and
+// select. It mirrors favoriteSelectorModel; the difference is the columns
+// rendered and that there is no config path in the help line.
+//
+// With multi set, several entries can be selected: space toggles the entry
+// under the cursor, shift+up/down extends the selection while moving, and "a"
+// toggles every entry shown.
 type entrySelectorModel struct {
 	entries []models.Entry
 
-	cursor   int
+	multi    bool
+	selected map[int]bool // selected entry indexes
+	explicit bool         // choose only the selected entries, never the one under the cursor
+
+	// Filtering.  visible holds the indexes of the entries shown, and
+	// highlights their matched characters.
+	filtering  bool
+	query      string
+	visible    []int
+	highlights [][][]int
+
+	// The optional ticket and note columns are only shown if an entry has one.
+	ticket bool
+	note   bool
+
+	cursor   int    // index into visible
 	numBuf   string // accumulates typed digits for jump-to-row
 	chosen   int    // selected index, or -1 if none
 	quitting bool
@@ -59,52 +84,168 @@ type entrySelectorModel struct {
 	caption string // action text, e.g. "Select an entry to amend"
 }
 
-func newEntrySelectorModel(caption string, entries []models.Entry) entrySelectorModel {
-	return entrySelectorModel{
-		entries: entries,
-		cursor:  0,
-		chosen:  -1,
-		caption: caption,
+func newEntrySelectorModel(caption string, entries []models.Entry, multi bool) entrySelectorModel {
+	var m entrySelectorModel = entrySelectorModel{
+		entries:  entries,
+		multi:    multi,
+		selected: make(map[int]bool),
+		cursor:   0,
+		chosen:   -1,
+		caption:  caption,
+	}
+
+	for _, entry := range entries {
+		m.ticket = m.ticket || len(entry.GetTicketAsString()) > 0
+		m.note = m.note || len(entry.Note) > 0
+	}
+
+	return m.applyFilter(-1)
+}
+
+// entrySearchFields returns the fields the filter matches against, in the
+// order renderTable highlights them.
+func entrySearchFields(entry models.Entry) []string {
+	return []string{entry.Project, entry.GetTasksAsString(), entry.GetTicketAsString(), entry.Note}
+}
+
+// applyFilter re-filters the entries with the current query, putting the
+// cursor on the given entry if it is still shown, or on the first row
+// otherwise.
+func (m entrySelectorModel) applyFilter(current int) entrySelectorModel {
+	var order []int = make([]int, len(m.entries))
+	for i := range order {
+		order[i] = i
+	}
+
+	m.visible, m.highlights = filterRows(m.query, order, func(i int) []string {
+		return entrySearchFields(m.entries[i])
+	})
+
+	m.cursor = 0
+	for i, index := range m.visible {
+		if index == current {
+			m.cursor = i
+		}
+	}
+
+	return m
+}
+
+// current returns the index of the entry under the cursor, or -1 if no
+// entries are shown.
+func (m entrySelectorModel) current() int {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return -1
+	}
+
+	return m.visible[m.cursor]
+}
+
+// chosenIndexes returns the selected entry indexes in order or, if none are
+// selected and the choice is not explicit, the entry under the cursor.
+func (m entrySelectorModel) chosenIndexes() []int {
+	var chosen []int
+	for i := range m.entries {
+		if m.selected[i] {
+			chosen = append(chosen, i)
+		}
+	}
+
+	if len(chosen) == 0 && !m.explicit && m.current() >= 0 {
+		chosen = append(chosen, m.current())
+	}
+
+	return chosen
+}
+
+// toggleShown selects every entry shown or, if they are all selected
+// already, unselects them.
+func (m entrySelectorModel) toggleShown() entrySelectorModel {
+	var all bool = true
+	for _, index := range m.visible {
+		all = all && m.selected[index]
+	}
+
+	for _, index := range m.visible {
+		m.selected[index] = !all
 	}
+
+	return m
 }
 
 // renderTable builds the go-pretty table string with the cursor row
 // highlighted. The "#" column is displayed 1-based to match the original amend
 // listing, while the cursor still tracks the 0-based slice index internally.
+// When selecting several entries, a leading column marks the selected ones.
 func (m entrySelectorModel) renderTable() string {
 	t := table.NewWriter()
 
-	t.AppendHeader(table.Row{"#", constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, constants.DATE_TIME_NORMAL_CASE})
+	var header table.Row = table.Row{"#", constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE}
+	if m.multi {
+		header = append(table.Row{""}, header...)
+	}
+	if m.ticket {
+		header = append(header, constants.TICKET_NORMAL_CASE)
+	}
+	if m.note {
+		header = append(header, constants.NOTE_NORMAL_CASE)
+	}
+	t.AppendHeader(append(header, constants.DATE_TIME_NORMAL_CASE))
 
 	style := table.StyleDefault
 	style.Format.Header = text.FormatUpper
 	t.SetStyle(style)
 
+	var numberColumn int = 1
+	if m.multi {
+		numberColumn = 2
+	}
 	t.SetColumnConfigs([]table.ColumnConfig{
-		{Number: 1, WidthMin: 3, WidthMax: 5},
+		{Number: numberColumn, WidthMin: 3, WidthMax: 5},
 	})
 
-	for i, entry := range m.entries {
+	for i, index := range m.visible {
 		// Display number is 1-based; store it as an int so the row painter can
-		// match against the cursor (cursor+1).
-		t.AppendRow(table.Row{
-			i + 1,
-			entry.Project,
-			entry.GetTasksAsString(),
-			carbon.Parse(entry.EntryDatetime).SetTimezone(carbon.Local).ToIso8601String(),
-		})
+		// match against the current entry (current+1).
+		var entry models.Entry = m.entries[index]
+		var row table.Row = table.Row{
+			index + 1,
+			highlightField(entry.Project, m.highlights[i], 0),
+			highlightField(entry.GetTasksAsString(), m.highlights[i], 1),
+		}
+		if m.ticket {
+			row = append(row, highlightField(entry.GetTicketAsString(), m.highlights[i], 2))
+		}
+		if m.note {
+			row = append(row, highlightField(entry.Note, m.highlights[i], 3))
+		}
+		row = append(row, carbon.Parse(entry.EntryDatetime).SetTimezone(carbon.Local).ToIso8601String())
+
+		if m.multi {
+			var mark string = "[ ]"
+			if m.selected[index] {
+				mark = "[x]"
+			}
+			row = append(table.Row{mark}, row...)
+		}
+
+		t.AppendRow(row)
 	}
 
+	// The painter is given each row's 1-based position in the table, which
+	// matches the 0-based cursor plus one.
 	cursor := m.cursor
-	t.SetRowPainter(func(row table.Row) text.Colors {
-		// row[0] is the 1-based "#"; the cursor is 0-based, so compare to cursor+1.
-		if len(row) > 0 {
-			if idx, ok := row[0].(int); ok && idx == cursor+1 {
-				return text.Colors{text.BgBlue, text.FgHiWhite}
-			}
+	visible := m.visible
+	selected := m.selected
+	t.SetRowPainter(table.RowPainterWithAttributes(func(row table.Row, attr table.RowAttributes) text.Colors {
+		if attr.Number == cursor+1 {
+			return text.Colors{text.BgBlue, text.FgHiWhite}
+		}
+		if attr.Number >= 1 && attr.Number <= len(visible) && selected[visible[attr.Number-1]] {
+			return text.Colors{text.FgHiGreen}
 		}
 		return text.Colors{}
-	})
+	}))
 
 	return t.Render()
 }
@@ -114,8 +255,59 @@ func (m entrySelectorModel) Init() tea.Cmd { return nil }
 func (m entrySelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
+		if m.multi {
+			switch msg.String() {
+			case " ":
+				if m.current() >= 0 {
+					m.selected[m.current()] = !m.selected[m.current()]
+				}
+				return m, nil
+
+			case "shift+up", "K":
+				if m.current() >= 0 {
+					m.selected[m.current()] = true
+				}
+				if m.cursor > 0 {
+					m.cursor--
+					m.selected[m.current()] = true
+				}
+				return m, nil
+
+			case "shift+down", "J":
+				if m.current() >= 0 {
+					m.selected[m.current()] = true
+				}
+				if m.cursor < len(m.visible)-1 {
+					m.cursor++
+					m.selected[m.current()] = true
+				}
+				return m, nil
+
+			case "a":
+				return m.toggleShown(), nil
+			}
+		}
+
 		switch msg.String() {
-		case "ctrl+c", "q", "esc":
+		case "/":
+			m.filtering = true
+			m.numBuf = ""
+			return m, nil
+
+		case "esc":
+			// The first esc clears the filter; the next one cancels.
+			if m.query != "" {
+				m.query = ""
+				return m.applyFilter(m.current()), nil
+			}
+			m.quitting = true
+			return m, tea.Quit
+
+		case "ctrl+c", "q":
 			m.quitting = true
 			return m, tea.Quit
 
@@ -126,24 +318,29 @@ func (m entrySelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "down", "j":
-			if m.cursor < len(m.entries)-1 {
+			if m.cursor < len(m.visible)-1 {
 				m.cursor++
 			}
 			return m, nil
 
 		case "enter":
 			if m.numBuf != "" {
-				// The user types the 1-based number they see; convert to the
-				// 0-based cursor index.
+				// The user types the 1-based number they see; move the cursor
+				// to that entry if it is shown.
 				if n, err := strconv.Atoi(m.numBuf); err == nil {
-					if n >= 1 && n <= len(m.entries) {
-						m.cursor = n - 1
+					for i, index := range m.visible {
+						if index == n-1 {
+							m.cursor = i
+						}
 					}
 				}
 				m.numBuf = ""
 				return m, nil
 			}
-			m.chosen = m.cursor
+			if m.current() < 0 {
+				return m, nil
+			}
+			m.chosen = m.current()
 			m.quitting = true
 			return m, tea.Quit
 
@@ -162,6 +359,45 @@ func (m entrySelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateFilter handles a key while the filter is being typed.  The table
+// narrows as the query changes; enter keeps the filter and goes back to
+// browsing, and esc clears it.
+func (m entrySelectorModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		m.filtering = false
+		m.query = ""
+		return m.applyFilter(m.current()), nil
+
+	case tea.KeyEnter:
+		m.filtering = false
+		return m, nil
+
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case tea.KeyDown:
+		if m.cursor < len(m.visible)-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+
+	if query, ok := editFilterQuery(m.query, msg); ok {
+		m.query = query
+		return m.applyFilter(-1), nil
+	}
+
+	return m, nil
+}
+
 func (m entrySelectorModel) View() string {
 	if m.quitting {
 		return ""
@@ -170,6 +406,9 @@ func (m entrySelectorModel) View() string {
 	var b strings.Builder
 	b.WriteString(m.renderTable())
 	b.WriteString("\n")
+	if m.filtering || m.query != "" {
+		b.WriteString(filterLine(m.filtering, m.query, len(m.visible)))
+	}
 	b.WriteString(m.helpLine())
 	b.WriteString("\n")
 	return b.String()
@@ -178,9 +417,17 @@ func (m entrySelectorModel) View() string {
 func (m entrySelectorModel) helpLine() string {
 	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 
-	keys := "up/down: navigate - enter: select - type a number + enter: jump - q/esc: cancel"
-	if m.numBuf != "" {
+	keys := "up/down: navigate - enter: select - type a number + enter: jump - /: filter - q/esc: cancel"
+	if m.multi {
+		keys = "up/down: navigate - space: toggle - shift+up/down: select range - a: toggle all shown - /: filter - enter: done (" +
+			strconv.Itoa(len(m.chosenIndexes())) + " chosen) - q/esc: cancel"
+	}
+	if m.filtering {
+		keys = "type to filter - up/down: navigate - enter: done - esc: clear"
+	} else if m.numBuf != "" {
 		keys = "jump to row: " + m.numBuf + "  (enter to go, backspace to edit)"
+	} else if m.query != "" {
+		keys = "up/down: navigate - enter: select - /: filter - esc: clear filter - q: cancel"
 	}
 
 	var b strings.Builder
@@ -196,7 +443,9 @@ func (m entrySelectorModel) helpLine() string {
 // index (0-based, into the entries slice) along with ok=true. On cancel it
 // returns (-1, false).
 func selectEntry(caption string, entries []models.Entry) (int, bool, error) {
-	m := newEntrySelectorModel(caption, entries)
+	requireInteractive("selecting an entry is required", constants.EXIT_SELECTION_REQUIRED)
+
+	m := newEntrySelectorModel(caption, entries, false)
 
 	// Inline (no alt-screen): renders in normal terminal flow.
 	p := tea.NewProgram(m)
@@ -211,3 +460,91 @@ func selectEntry(caption string, entries []models.Entry) (int, bool, error) {
 	}
 	return em.chosen, true, nil
 }
+
+// selectEntries launches the interactive entry selector letting the user
+// select several entries, and returns the chosen indexes (0-based, into the
+// entries slice, in order) along with ok=true.  If no entries were selected,
+// the one under the cursor is chosen.  With selectAll, every entry starts
+// selected, and unselecting them all is the same as cancelling.  On cancel it
+// returns (nil, false).
+func selectEntries(caption string, entries []models.Entry, selectAll bool) ([]int, bool, error) {
+	requireInteractive("selecting entries is required; use --all instead", constants.EXIT_SELECTION_REQUIRED)
+
+	m := newEntrySelectorModel(caption, entries, true)
+	if selectAll {
+		m = m.toggleShown()
+		m.explicit = true
+	}
+
+	// Inline (no alt-screen): renders in normal terminal flow.
+	p := tea.NewProgram(m)
+	final, err := p.Run()
+	if err != nil {
+		return nil, false, err
+	}
+
+	em, ok := final.(entrySelectorModel)
+	if !ok || em.chosen < 0 {
+		return nil, false, nil
+	}
+
+	chosen := em.chosenIndexes()
+	if len(chosen) == 0 {
+		return nil, false, nil
+	}
+	return chosen, true, nil
+}
+
+// chooseEntry picks the entry a command should work on.  If the command has a
+// --uid flag and it was given, that entry is used.  If the --today or --date
+// flag was given, that day's entries are shown in the interactive selector;
+// otherwise, the last entry is used.  It returns ok=false if there was nothing
+// to choose or the user cancelled.
+func chooseEntry(cmd *cobra.Command, db *database.Database, caption string) (models.Entry, bool) {
+	if cmd.Flags().Lookup(constants.FLAG_UID) != nil {
+		uid, _ := cmd.Flags().GetInt64(constants.FLAG_UID)
+		if uid != constants.UNKNOWN_UID {
+			var entry models.Entry = db.GetEntry(uid)
+			if entry.Uid != uid {
+				log.Fatalf("%s: Entry[%d] not found.\n", color.RedString(constants.FATAL_NORMAL_CASE), uid)
+				os.Exit(1)
+			}
+
+			return entry, true
+		}
+	}
+
+	today, _ := cmd.Flags().GetBool(constants.FLAG_TODAY)
+	givenDate, _ := cmd.Flags().GetString(constants.FLAG_DATE)
+
+	if !today && stringUtils.IsEmpty(givenDate) {
+		// Get the last Entry from the database.
+		var entry models.Entry = db.GetLastEntry()
+		return entry, entry.Uid != constants.UNKNOWN_UID
+	}
+
+	var entries []models.Entry
+	if today {
+		entries = db.GetEntriesForToday(*carbon.Now().StartOfDay(), *carbon.Now().EndOfDay())
+	} else {
+		entries = db.GetEntriesForToday(*carbon.Parse(givenDate).StartOfDay(), *carbon.Parse(givenDate).EndOfDay())
+	}
+
+	if len(entries) == 0 {
+		log.Printf("%s\n", color.YellowString("No entries found."))
+		return models.Entry{}, false
+	}
+
+	// Show the entries in an interactive selector and let the user pick one.
+	idx, ok, err := selectEntry(caption, entries)
+	if err != nil {
+		log.Fatalf("%s: Error running entry selector. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	if !ok {
+		return models.Entry{}, false
+	}
+
+	return entries[idx], true
+}
@@ -59,6 +59,7 @@ var breakCmd = &cobra.Command{
 func init() {
 	breakCmd.Flags().StringVarP(&at, constants.AT, constants.EMPTY, constants.EMPTY, constants.NATURAL_LANGUAGE_DESCRIPTION)
 	breakCmd.Flags().StringVarP(&note, constants.NOTE, constants.EMPTY, constants.EMPTY, constants.NOTE_DESCRIPTION)
+	breakCmd.Flags().BoolP(constants.FLAG_FORCE, constants.EMPTY, false, constants.FLAG_FORCE_DESCRIPTION)
 	rootCmd.AddCommand(breakCmd)
 
 	// Here you will define your flags and configuration settings.
@@ -95,12 +96,21 @@ func runBreak(cmd *cobra.Command, _ []string) {
 	var entry models.Entry = models.NewEntry(constants.UNKNOWN_UID, constants.BREAK, note,
 		breakTime.ToIso8601String(carbon.UTC))
 
+	// Classify the break with the rules in the configuration.
+	entry = applyRulesOnAdd(entry)
+
+	// Make sure the break fits in the timeline.
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	if !validateTimeline(cmd, db, entry) {
+		log.Printf("%s\n", color.YellowString("Nothing added."))
+		return
+	}
+
 	// Prompt the user to make sure they still want to add the new break.
 	log.Printf("You are about to add this break\n%s...\n\n", entry.Dump(true, constants.INDENT_AMOUNT))
 	yesNo := yesNoPrompt("Continue?")
 	if yesNo {
 		// Yes, they want the break added. Write the new Entry to the database.
-		db := database.New(viper.GetString(constants.DATABASE_FILE))
 		db.InsertNewEntry(entry)
 		log.Printf("%s.\n", color.GreenString("Break added"))
 
@@ -0,0 +1,434 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"khronos/constants"
+	"khronos/internal/database"
+	"khronos/internal/models"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// bulkCmd represents the bulk command.
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.BULK_SHORT_DESCRIPTION,
+	Long:  constants.BULK_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runBulk(cmd, args)
+	},
+}
+
+func init() {
+	bulkCmd.Flags().BoolP(constants.FLAG_TODAY, constants.EMPTY, false, "Choose from today's entries.  This is the default.")
+	bulkCmd.Flags().StringP(constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "Choose from the entries for the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	bulkCmd.Flags().StringP(constants.FLAG_FROM, constants.EMPTY, constants.EMPTY, "Choose from the entries starting at the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	bulkCmd.Flags().StringP(constants.FLAG_TO, constants.EMPTY, constants.EMPTY, "Choose from the entries up to the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format, default is today.")
+	bulkCmd.Flags().BoolP(constants.FLAG_ALL, constants.EMPTY, false, "Use all the entries, apart from hellos, instead of choosing them interactively.")
+	bulkCmd.Flags().StringP(constants.FLAG_PROJECT, constants.EMPTY, constants.EMPTY, "Change the project of the entries.")
+	bulkCmd.Flags().StringP(constants.TASK, constants.EMPTY, constants.EMPTY, "Change the task of the entries.")
+	bulkCmd.Flags().StringP(constants.FLAG_TICKET, constants.EMPTY, constants.EMPTY, "Change the ticket of the entries, e.g., ABC-123.  Use \"\" to remove it.")
+	bulkCmd.Flags().StringP(constants.NOTE, constants.EMPTY, constants.EMPTY, "Change the note of the entries.  Use \"\" to remove it.")
+	bulkCmd.Flags().BoolP(constants.FLAG_DELETE, constants.EMPTY, false, "Delete the entries.")
+	bulkCmd.MarkFlagsMutuallyExclusive(constants.FLAG_TODAY, constants.FLAG_DATE, constants.FLAG_FROM)
+	bulkCmd.MarkFlagsMutuallyExclusive(constants.FLAG_TODAY, constants.FLAG_DATE, constants.FLAG_TO)
+	for _, name := range []string{constants.FLAG_PROJECT, constants.TASK, constants.FLAG_TICKET, constants.NOTE} {
+		bulkCmd.MarkFlagsMutuallyExclusive(constants.FLAG_DELETE, name)
+	}
+	rootCmd.AddCommand(bulkCmd)
+}
+
+// bulkChange is the change made to every chosen entry.  Only the fields that
+// are set are changed.
+type bulkChange struct {
+	project, task, ticket, note             string
+	setProject, setTask, setTicket, setNote bool
+}
+
+func runBulk(cmd *cobra.Command, _ []string) {
+	start, end := bulkRange(cmd)
+	deleting, _ := cmd.Flags().GetBool(constants.FLAG_DELETE)
+	all, _ := cmd.Flags().GetBool(constants.FLAG_ALL)
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+
+	// Hellos mark the start of the day rather than work, so they are left
+	// alone.
+	var entries []models.Entry
+	for _, entry := range db.GetEntriesForToday(start, end) {
+		if entry.Project != constants.HELLO {
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) == 0 {
+		log.Printf("%s\n", color.YellowString("No entries found."))
+		return
+	}
+
+	var chosen []models.Entry = entries
+	if !all {
+		var caption string = "Select the entries to amend"
+		if deleting {
+			caption = "Select the entries to delete"
+		}
+
+		indexes, ok, err := selectEntries(caption, entries, false)
+		if err != nil {
+			log.Fatalf("%s: Error running entry selector. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		if !ok {
+			log.Printf("%s\n", color.YellowString("No entries changed."))
+			return
+		}
+
+		chosen = nil
+		for _, i := range indexes {
+			chosen = append(chosen, entries[i])
+		}
+	}
+
+	if deleting {
+		runBulkDelete(db, chosen)
+	} else {
+		runBulkAmend(cmd, db, chosen)
+	}
+}
+
+// bulkRange returns the start and end of the days to choose entries from.
+func bulkRange(cmd *cobra.Command) (carbon.Carbon, carbon.Carbon) {
+	givenDate, _ := cmd.Flags().GetString(constants.FLAG_DATE)
+	from, _ := cmd.Flags().GetString(constants.FLAG_FROM)
+	to, _ := cmd.Flags().GetString(constants.FLAG_TO)
+
+	var start carbon.Carbon = *carbon.Now()
+	var end carbon.Carbon = *carbon.Now()
+
+	if !stringUtils.IsEmpty(givenDate) {
+		start = parseBulkDate(givenDate)
+		end = start
+	}
+
+	if !stringUtils.IsEmpty(from) {
+		start = parseBulkDate(from)
+	}
+
+	if !stringUtils.IsEmpty(to) {
+		end = parseBulkDate(to)
+	}
+
+	if end.Lt(&start) {
+		log.Fatalf("%s: --%s[%s] must not be before --%s[%s].\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.FLAG_TO, to, constants.FLAG_FROM, from)
+		os.Exit(1)
+	}
+
+	return *start.StartOfDay(), *end.EndOfDay()
+}
+
+func parseBulkDate(date string) carbon.Carbon {
+	var day carbon.Carbon = *carbon.Parse(date)
+	if day.Error != nil {
+		log.Fatalf("%s: Invalid date[%s].  Please use %s format.\n", color.RedString(constants.FATAL_NORMAL_CASE), date, constants.DATE_FORMAT_YYYY_MM_DD)
+		os.Exit(1)
+	}
+
+	return day
+}
+
+func runBulkAmend(cmd *cobra.Command, db *database.Database, chosen []models.Entry) {
+	var change bulkChange = bulkChangeFromFlags(cmd)
+	if !change.setProject && !change.setTask && !change.setTicket && !change.setNote {
+		change = promptBulkChange()
+	}
+
+	if change.setTicket && !stringUtils.IsEmpty(change.ticket) && !ticketRegex.MatchString(change.ticket) {
+		log.Fatalf("%s: Malformed ticket[%s], expected a key such as ABC-123.\n", color.RedString(constants.FATAL_NORMAL_CASE), change.ticket)
+		os.Exit(1)
+	}
+
+	// Create a table to show the old verses new values.
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{"", constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, constants.NOTE_NORMAL_CASE,
+		constants.TICKET_NORMAL_CASE, constants.DATE_TIME_NORMAL_CASE})
+
+	var amended []models.Entry
+	var pushed int
+	for _, entry := range chosen {
+		var e models.Entry = change.apply(entry)
+		if e.Project == entry.Project && e.Note == entry.Note && e.GetTasksAsString() == entry.GetTasksAsString() &&
+			e.GetTicketAsString() == entry.GetTicketAsString() {
+			continue
+		}
+		amended = append(amended, e)
+
+		if entry.IsPushed() {
+			pushed++
+		}
+
+		t.AppendRow(table.Row{"Old", entry.Project, entry.GetTasksAsString(), entry.Note, entry.GetTicketAsString(),
+			carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local)})
+		t.AppendRow(table.Row{"New", e.Project, e.GetTasksAsString(), e.Note, e.GetTicketAsString(),
+			carbon.Parse(e.EntryDatetime).ToIso8601String(carbon.Local)})
+		t.AppendSeparator()
+	}
+
+	if len(amended) == 0 {
+		log.Printf("%s\n", color.YellowString("Nothing to change."))
+		return
+	}
+
+	// Render the table.
+	log.Printf("\n")
+	log.Println(t.Render())
+
+	if pushed > 0 {
+		log.Printf("%s: %d of the entries were already pushed; their worklogs are not changed.\n",
+			color.YellowString("Warning"), pushed)
+	}
+
+	// Ask the user if they want to commit these changes or not.
+	yesNo := yesNoPrompt("\nCommit these changes to %d entries?", len(amended))
+	if yesNo {
+		db.UpdateEntries(amended)
+		log.Printf("%s\n", color.GreenString("%d entries amended.", len(amended)))
+	} else {
+		log.Printf("%s\n", color.YellowString("Entries NOT amended."))
+	}
+}
+
+// bulkChangeFromFlags returns the change given on the command line.
+func bulkChangeFromFlags(cmd *cobra.Command) bulkChange {
+	var change bulkChange
+
+	change.setProject = cmd.Flags().Changed(constants.FLAG_PROJECT)
+	change.project, _ = cmd.Flags().GetString(constants.FLAG_PROJECT)
+	change.setTask = cmd.Flags().Changed(constants.TASK)
+	change.task, _ = cmd.Flags().GetString(constants.TASK)
+	change.setTicket = cmd.Flags().Changed(constants.FLAG_TICKET)
+	change.ticket, _ = cmd.Flags().GetString(constants.FLAG_TICKET)
+	change.setNote = cmd.Flags().Changed(constants.NOTE)
+	change.note, _ = cmd.Flags().GetString(constants.NOTE)
+
+	if change.setProject && stringUtils.IsBlank(change.project) {
+		log.Fatalf("%s: --%s must not be empty.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.FLAG_PROJECT)
+		os.Exit(1)
+	}
+
+	if change.setTask && stringUtils.IsBlank(change.task) {
+		log.Fatalf("%s: --%s must not be empty.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.TASK)
+		os.Exit(1)
+	}
+
+	return change
+}
+
+// promptBulkChange asks for the change to make.  An empty answer leaves that
+// field as it is.
+func promptBulkChange() bulkChange {
+	var change bulkChange
+
+	change.project = prompt(constants.PROJECT_NORMAL_CASE, constants.EMPTY)
+	change.setProject = !stringUtils.IsEmpty(change.project)
+
+	// Break and untracked time have neither tasks nor tickets.
+	if !isBreakOrUntracked(change.project) {
+		change.task = prompt(constants.TASK_NORMAL_CASE, constants.EMPTY)
+		change.setTask = !stringUtils.IsEmpty(change.task)
+
+		change.ticket = prompt(constants.TICKET_NORMAL_CASE, constants.EMPTY)
+		change.setTicket = !stringUtils.IsEmpty(change.ticket)
+	}
+
+	change.note = prompt(constants.NOTE_NORMAL_CASE, constants.EMPTY)
+	change.setNote = !stringUtils.IsEmpty(change.note)
+
+	return change
+}
+
+// apply returns a copy of the entry with the change made.  The entry's other
+// properties, e.g., tags, are kept, and break and untracked time keep having
+// no task or ticket.  An entry gains a pushed property when it
+// gets a ticket, so it can be pushed, and loses it when its ticket is removed,
+// unless it was already pushed.
+func (c bulkChange) apply(entry models.Entry) models.Entry {
+	var e models.Entry = models.NewEntry(entry.Uid, entry.Project, entry.Note, entry.EntryDatetime)
+	if c.setProject {
+		e.Project = c.project
+	}
+	if c.setNote {
+		e.Note = c.note
+	}
+
+	for _, p := range entry.Properties {
+		if p.Name != constants.TASK && p.Name != constants.TICKET && p.Name != constants.PUSHED {
+			e.AddEntryProperty(p.Name, p.Value)
+		}
+	}
+
+	// Break and untracked time have neither tasks nor tickets.
+	if isBreakOrUntracked(e.Project) {
+		return e
+	}
+
+	if c.setTask {
+		e.AddEntryProperty(constants.TASK, c.task)
+	} else {
+		for _, p := range entry.Properties {
+			if p.Name == constants.TASK {
+				e.AddEntryProperty(p.Name, p.Value)
+			}
+		}
+	}
+
+	var ticket string = entry.GetTicketAsString()
+	if c.setTicket {
+		ticket = c.ticket
+	}
+
+	var pushed string = entry.GetPushedAsString()
+	if !stringUtils.IsEmpty(ticket) {
+		e.AddEntryProperty(constants.TICKET, ticket)
+		e.AddEntryProperty(constants.PUSHED, pushed)
+	} else if !stringUtils.IsEmpty(pushed) {
+		e.AddEntryProperty(constants.PUSHED, pushed)
+	}
+
+	return e
+}
+
+func isBreakOrUntracked(project string) bool {
+	return strings.EqualFold(project, constants.BREAK) || strings.EqualFold(project, constants.UNTRACKED)
+}
+
+func runBulkDelete(db *database.Database, chosen []models.Entry) {
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, constants.NOTE_NORMAL_CASE,
+		constants.TICKET_NORMAL_CASE, constants.DATE_TIME_NORMAL_CASE})
+
+	var uids []int64
+	var pushed int
+	for _, entry := range chosen {
+		uids = append(uids, entry.Uid)
+
+		if entry.IsPushed() {
+			pushed++
+		}
+
+		t.AppendRow(table.Row{entry.Project, entry.GetTasksAsString(), entry.Note, entry.GetTicketAsString(),
+			carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local)})
+	}
+
+	log.Printf("You are about to delete these entries\n\n%s\n\n", t.Render())
+	showBulkDeleteDurationChanges(db, chosen)
+
+	if pushed > 0 {
+		log.Printf("%s: %d of the entries were already pushed; their worklogs are not removed.\n",
+			color.YellowString("Warning"), pushed)
+	}
+
+	yesNo := yesNoPrompt("Delete %d entries?", len(uids))
+	if yesNo {
+		db.ReplaceEntries(uids, []models.Entry{})
+		log.Printf("%s\n", color.GreenString("%d entries deleted.", len(uids)))
+	} else {
+		log.Printf("%s\n", color.YellowString("Entries NOT deleted."))
+	}
+}
+
+// showBulkDeleteDurationChanges renders the durations of the entries that
+// grow once the chosen entries are deleted, since each entry's duration runs
+// from the entry before it.
+func showBulkDeleteDurationChanges(db *database.Database, chosen []models.Entry) {
+	var deleted map[int64]bool = make(map[int64]bool)
+	for _, entry := range chosen {
+		deleted[entry.Uid] = true
+	}
+
+	// The chosen entries are in order; look from the entry before the first
+	// one to the entry after the last one.
+	var from string = chosen[0].EntryDatetime
+	if before := db.GetEntryBefore(from); before.Uid != constants.UNKNOWN_UID {
+		from = before.EntryDatetime
+	}
+
+	var to string = chosen[len(chosen)-1].EntryDatetime
+	if after := db.GetEntryAfter(to); after.Uid != constants.UNKNOWN_UID {
+		to = after.EntryDatetime
+	}
+
+	var before []models.Entry = db.GetEntriesForToday(*carbon.Parse(from), *carbon.Parse(to))
+	var after []models.Entry
+	for _, e := range before {
+		if !deleted[e.Uid] {
+			after = append(after, e)
+		}
+	}
+
+	var beforeDurations map[int64]int64 = timelineDurations(before)
+	var afterDurations map[int64]int64 = timelineDurations(after)
+
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, constants.DATE_TIME_NORMAL_CASE, "Old " + constants.DURATION_NORMAL_CASE, "New " + constants.DURATION_NORMAL_CASE})
+
+	var changed bool = false
+	for _, e := range after {
+		// A hello starts the day, so it does not have a duration worth showing.
+		if e.Project == constants.HELLO {
+			continue
+		}
+
+		duration, found := afterDurations[e.Uid]
+		if oldDuration, wasFound := beforeDurations[e.Uid]; found && wasFound && oldDuration != duration {
+			changed = true
+			t.AppendRow(table.Row{e.Project, e.GetTasksAsString(), carbon.Parse(e.EntryDatetime).ToIso8601String(carbon.Local),
+				formatSeconds(oldDuration), formatSeconds(duration)})
+		}
+	}
+
+	if changed {
+		log.Printf("The time of the deleted entries moves to the entries after them as follows\n\n%s\n\n", t.Render())
+	}
+}
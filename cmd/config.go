@@ -0,0 +1,536 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"khronos/internal/push"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configCmd represents the config command.
+var configCmd = &cobra.Command{
+	Use:     "config",
+	Aliases: []string{"c", "configure", "conf"},
+	Args:    cobra.ExactArgs(0),
+	Short:   constants.CONFIG_SHORT_DESCRIPTION,
+	Long:    constants.CONFIG_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Printf("Config file is at \"%s\"\n", viper.ConfigFileUsed())
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "List every setting, its effective value, and where it comes from",
+	Long:  "List every setting, its effective value, and where it comes from: the configuration file, an environment variable, e.g., ROUND_TO_MINUTES, or the default.",
+	Run: func(cmd *cobra.Command, args []string) {
+		runConfigList()
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get key",
+	Args:  cobra.ExactArgs(1),
+	Short: "Print the effective value of a setting",
+	Run: func(cmd *cobra.Command, args []string) {
+		runConfigGet(args[0])
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set key value",
+	Args:  cobra.ExactArgs(2),
+	Short: "Set a setting in the configuration file",
+	Long:  "Set a setting in the configuration file, leaving the rest of the file, including its comments and favorites, as it is.  An empty value removes the setting, so its default is used.",
+	Run: func(cmd *cobra.Command, args []string) {
+		runConfigSet(args[0], args[1])
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Args:  cobra.ExactArgs(0),
+	Short: "Check the configuration file for problems",
+	Run: func(cmd *cobra.Command, args []string) {
+		runConfigValidate()
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Args:  cobra.ExactArgs(0),
+	Short: "Walk through the main settings and write them to the configuration file",
+	Long:  "Walk through the database location, week start, rounding, time format, and Jira settings, then write the ones changed to the configuration file.",
+	Run: func(cmd *cobra.Command, args []string) {
+		runConfigInit()
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configListCmd, configGetCmd, configSetCmd, configValidateCmd, configInitCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigList() {
+	var keys []string
+	for key, setting := range configSchema {
+		if setting.kind != configMapping {
+			keys = append(keys, key)
+		}
+	}
+
+	// Settings in the file that Khronos does not know about are listed too,
+	// so nothing in effect is hidden.
+	for _, key := range viper.AllKeys() {
+		if _, known := configSchema[key]; !known && viper.InConfig(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	// The descriptions are only shown when there is room for them.
+	var describe bool = terminalWidth-76 >= 30
+
+	var t table.Writer = table.NewWriter()
+	SetReportTableStyle(t)
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 2, WidthMax: 30, WidthMaxEnforcer: text.WrapHard},
+		{Number: 4, WidthMax: terminalWidth - 76, WidthMaxEnforcer: text.WrapSoft},
+	})
+	if describe {
+		t.AppendHeader(table.Row{"Key", "Value", "Source", "Description"})
+	} else {
+		t.AppendHeader(table.Row{"Key", "Value", "Source"})
+	}
+
+	for _, key := range keys {
+		var row table.Row = table.Row{key, configDisplayValue(key), configSource(key)}
+		if describe {
+			var description string = "Unknown setting."
+			if setting, known := configSchema[key]; known {
+				description = setting.description
+			}
+			row = append(row, description)
+		}
+
+		t.AppendRow(row)
+	}
+
+	log.Printf("Settings from \"%s\"\n", viper.ConfigFileUsed())
+	for _, layer := range configLayers {
+		log.Printf("Merged with \"%s\"\n", layer.path)
+	}
+	log.Printf("\n%s\n", t.Render())
+}
+
+func runConfigGet(key string) {
+	key = strings.ToLower(key)
+	if _, known := configSchema[key]; !known && !viper.IsSet(key) {
+		configUnknownKey(key)
+	}
+
+	var value any = viper.Get(key)
+	switch value.(type) {
+	case map[string]any, []any:
+		data, err := yaml.Marshal(value)
+		favoriteFatalIfError(err)
+		fmt.Print(string(data))
+	case nil:
+		// Nothing set, and no default.
+	default:
+		fmt.Println(viper.GetString(key))
+	}
+}
+
+func runConfigSet(key string, value string) {
+	key = strings.ToLower(key)
+	setting, known := configSchema[key]
+	if !known {
+		configUnknownKey(key)
+	}
+
+	switch setting.kind {
+	case configFavorites:
+		log.Fatalf("%s: The favorites cannot be set directly, use the favorite command instead.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+		os.Exit(1)
+	case configWorkspaces, configRules, configPushTargets:
+		log.Fatalf("%s: The %s cannot be set directly, use the edit command instead.\n", color.RedString(constants.FATAL_NORMAL_CASE), key)
+		os.Exit(1)
+	case configMapping:
+		log.Fatalf("%s: %s holds other settings and cannot be set directly, set one of them instead, e.g., %s.url.\n",
+			color.RedString(constants.FATAL_NORMAL_CASE), key, key)
+		os.Exit(1)
+	}
+
+	value, tag, err := parseConfigValue(key, setting.kind, value)
+	favoriteFatalIfError(err)
+
+	favoriteFatalIfError(writeConfigSettings(viper.ConfigFileUsed(), []configChange{{key: key, value: value, tag: tag}}))
+
+	if value == constants.EMPTY {
+		log.Printf("%s\n", color.GreenString("%s removed, the default is used.", key))
+	} else {
+		log.Printf("%s\n", color.GreenString("%s set to %s.", key, configMask(key, value)))
+	}
+
+	if source := configSource(key); source == "env" {
+		log.Printf("%s: The %s environment variable overrides the configuration file.\n", color.YellowString("Warning"), strings.ToUpper(key))
+	} else if layer := configLayerOf(key); layer != nil {
+		log.Printf("%s: The configuration file[%s] overrides the global one here.\n", color.YellowString("Warning"), layer.path)
+	}
+}
+
+func runConfigValidate() {
+	problems, err := validateConfigFile(viper.ConfigFileUsed())
+	favoriteFatalIfError(err)
+
+	var valid bool = showConfigProblems(viper.ConfigFileUsed(), problems)
+	for _, layer := range configLayers {
+		data, err := os.ReadFile(layer.path)
+		favoriteFatalIfError(err)
+
+		valid = showConfigProblems(layer.path, validateLayerConfig(data)) && valid
+	}
+
+	if !valid {
+		os.Exit(1)
+	}
+}
+
+// showConfigProblems shows the problems found in a configuration file,
+// returning true if there were none.
+func showConfigProblems(path string, problems []configProblem) bool {
+	if len(problems) == 0 {
+		log.Printf("%s\n", color.GreenString("The configuration file \"%s\" is valid.", path))
+		return true
+	}
+
+	log.Printf("The configuration file \"%s\" has the following problems\n", path)
+	for _, problem := range problems {
+		log.Printf("    %s\n", problem)
+	}
+
+	return false
+}
+
+func runConfigInit() {
+	requireInteractive("the configuration wizard needs answers", constants.EXIT_INPUT_REQUIRED)
+
+	var path string = viper.ConfigFileUsed()
+	log.Printf("Answer each question, or press enter to keep the value shown.  Nothing is written until you confirm.\n\n")
+
+	var answers = map[string]string{constants.PUSH_TYPE: constants.PUSH_TYPE_JIRA}
+	for _, question := range configQuestions {
+		answers[question.key] = promptConfigValue(question.key, question.label)
+	}
+
+	var changes []configChange
+	var t table.Writer = table.NewWriter()
+	SetReportTableStyle(t)
+	t.AppendHeader(table.Row{"Key", "Old", "New"})
+	for _, key := range []string{constants.DATABASE_FILE, constants.WEEK_START, constants.ROUND_TO_MINUTES, constants.DISPLAY_TIME_IN_24H_FORMAT,
+		constants.PUSH_TYPE, constants.PUSH_URL, constants.PUSH_USERNAME, constants.PUSH_API_KEY} {
+		var kind configKind = configSchema[key].kind
+		value, tag, _ := parseConfigValue(key, kind, answers[key])
+		old, _, _ := parseConfigValue(key, kind, viper.GetString(key))
+		if value == old {
+			continue
+		}
+
+		changes = append(changes, configChange{key: key, value: value, tag: tag})
+		t.AppendRow(table.Row{key, configMask(key, viper.GetString(key)), configMask(key, value)})
+	}
+
+	if len(changes) == 0 {
+		log.Printf("\n%s\n", color.YellowString("No changes, the configuration file was NOT written."))
+		return
+	}
+
+	log.Printf("\n%s\n", t.Render())
+	if !yesNoPrompt("Write these settings to %s?", path) {
+		log.Printf("%s\n", color.YellowString("The configuration file was NOT written."))
+		return
+	}
+
+	favoriteFatalIfError(writeConfigSettings(path, changes))
+	log.Printf("%s\n", color.GreenString("The configuration file was written."))
+
+	if _, err := os.Stat(answers[constants.DATABASE_FILE]); os.IsNotExist(err) {
+		log.Printf("%s: The database file[%s] does not exist, it is created the next time Khronos runs.\n",
+			color.HiBlueString(constants.INFO_NORMAL_CASE), answers[constants.DATABASE_FILE])
+	}
+}
+
+// configQuestions are the settings config init asks for, in order.  The
+// push.type is always jira, so it is not asked for.
+var configQuestions = []struct {
+	key   string
+	label string
+}{
+	{constants.DATABASE_FILE, "the database file"},
+	{constants.WEEK_START, "the day the week starts on"},
+	{constants.ROUND_TO_MINUTES, "the minutes reports round to, 0 for none"},
+	{constants.DISPLAY_TIME_IN_24H_FORMAT, "if times are displayed in 24 hour format, true or false"},
+	{constants.PUSH_URL, "the Jira URL, e.g., https://acme.atlassian.net"},
+	{constants.PUSH_USERNAME, "the Jira username"},
+	{constants.PUSH_API_KEY, "the Jira API token"},
+}
+
+// promptConfigValue prompts for a setting until a valid value is entered,
+// returning it.  The API key is never shown.
+func promptConfigValue(key string, label string) string {
+	var current string = viper.GetString(key)
+
+	for {
+		var value string = prompt(label, configMask(key, current))
+		if value == configMask(key, current) {
+			value = current
+		}
+
+		value, _, err := parseConfigValue(key, configSchema[key].kind, value)
+		if err == nil {
+			return value
+		}
+
+		log.Printf("%s: %s.\n", color.YellowString("Warning"), err.Error())
+	}
+}
+
+// configChange is a setting to write to the configuration file.  An empty
+// value removes the setting.
+type configChange struct {
+	key   string
+	value string
+	tag   string
+}
+
+// writeConfigSettings writes the settings to the configuration file, creating
+// any mapping they are nested in.  The rest of the file is left as it is.  The
+// file is not written if the settings add a problem to it.
+func writeConfigSettings(path string, changes []configChange) error {
+	before, err := validateConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc, err := readConfigDocument(path)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		var mapping *yaml.Node = doc.Content[0]
+		var names []string = strings.Split(change.key, ".")
+		for _, name := range names[:len(names)-1] {
+			var value *yaml.Node = mappingValue(mapping, name)
+			if value == nil || value.Tag == "!!null" {
+				if value == nil {
+					value = &yaml.Node{}
+					mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, value)
+				}
+				*value = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			} else if value.Kind != yaml.MappingNode {
+				return fmt.Errorf("%s is not a mapping in configuration file[%s]", name, path)
+			}
+			mapping = value
+		}
+
+		setMappingValue(mapping, names[len(names)-1], change.value, change.tag)
+	}
+
+	data, err := encodeConfigDocument(doc)
+	if err != nil {
+		return fmt.Errorf("error marshaling configuration file[%s]. %s", path, err.Error())
+	}
+
+	// Only the problems the change adds are refused, so a file that already
+	// had problems can still be fixed one setting at a time.
+	var existing = map[string]bool{}
+	for _, problem := range before {
+		existing[problem.Message] = true
+	}
+	for _, problem := range validateConfig(data) {
+		if !existing[problem.Message] {
+			return fmt.Errorf("the configuration file was NOT written, it would have the problem: %s", problem.Message)
+		}
+	}
+
+	return writeConfigDocument(path, doc)
+}
+
+// parseConfigValue checks a value entered for a setting of the given kind,
+// returning it as it is written to the configuration file along with its
+// YAML tag.  An empty value is always allowed.
+func parseConfigValue(key string, kind configKind, value string) (string, string, error) {
+	value = strings.TrimSpace(value)
+	if value == constants.EMPTY {
+		return value, "!!str", nil
+	}
+
+	switch kind {
+	case configBool:
+		switch strings.ToLower(value) {
+		case "y", "yes", "on":
+			value = "true"
+		case "n", "no", "off":
+			value = "false"
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return value, "!!bool", fmt.Errorf("invalid %s[%s], must be true or false", key, value)
+		}
+		return strconv.FormatBool(b), "!!bool", nil
+	case configInt:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return value, "!!int", fmt.Errorf("invalid %s[%s], must be a whole number of zero or more", key, value)
+		}
+		return strconv.Itoa(n), "!!int", nil
+	}
+
+	switch key {
+	case constants.WEEK_START:
+		weekday, err := parseWeekday(value)
+		if err != nil {
+			return value, "!!str", fmt.Errorf("invalid %s[%s], must be a day of the week such as Sunday", key, value)
+		}
+		value = weekday.String()
+	case constants.COMMIT_HOOK:
+		if !strings.EqualFold(value, constants.COMMIT_HOOK_SUGGEST) && !strings.EqualFold(value, constants.COMMIT_HOOK_ENTRY) {
+			return value, "!!str", fmt.Errorf("invalid %s[%s], must be %s or %s", key, value, constants.COMMIT_HOOK_SUGGEST, constants.COMMIT_HOOK_ENTRY)
+		}
+		value = strings.ToLower(value)
+	case constants.FAVORITE_ORDER:
+		if !strings.EqualFold(value, constants.FAVORITE_ORDER_CONFIG) && !strings.EqualFold(value, constants.FAVORITE_ORDER_SMART) {
+			return value, "!!str", fmt.Errorf("invalid %s[%s], must be %s or %s", key, value, constants.FAVORITE_ORDER_CONFIG, constants.FAVORITE_ORDER_SMART)
+		}
+		value = strings.ToLower(value)
+	case constants.TICKET_NOTE_PROJECTS:
+		projects, err := parseTicketProjects(value)
+		if err != nil {
+			return value, "!!str", err
+		}
+		value = strings.Join(projects, ", ")
+	case constants.PUSH_TYPE:
+		if !push.IsType(value) {
+			return value, "!!str", fmt.Errorf("invalid %s[%s], must be %s", key, value, strings.Join(push.Types(), " or "))
+		}
+		value = strings.ToLower(value)
+	}
+
+	return value, "!!str", nil
+}
+
+// configSource returns where the effective value of a setting comes from:
+// an environment variable, which viper looks for by the upper cased key, the
+// innermost per-directory configuration file setting it, the global one, or
+// the default.  The favorites, rules, and workspaces come from every file that
+// has some.
+func configSource(key string) string {
+	if _, found := os.LookupEnv(strings.ToUpper(key)); found {
+		return "env"
+	}
+
+	if isLayerList(key) {
+		var sources []string
+		if viper.InConfig(key) {
+			sources = append(sources, "global")
+		}
+		for _, layer := range configLayers {
+			if layer.listLength(key) > 0 {
+				sources = append(sources, configLayerLabel(layer.path))
+			}
+		}
+		if len(sources) > 0 {
+			return strings.Join(sources, ", ")
+		}
+	} else if layer := configLayerOf(key); layer != nil {
+		return configLayerLabel(layer.path)
+	} else if viper.InConfig(key) {
+		return "global"
+	}
+
+	return "default"
+}
+
+// configDisplayValue returns the effective value of a setting as shown by
+// config list.
+func configDisplayValue(key string) string {
+	if key == constants.FAVORITES {
+		return strings.TrimSpace(plural(len(loadFavorites()), "favorite"))
+	} else if key == constants.WORKSPACES {
+		return strings.TrimSpace(plural(len(loadWorkspaces()), "workspace"))
+	} else if key == constants.RULES {
+		return strings.TrimSpace(plural(len(loadRules()), "rule"))
+	} else if key == constants.PUSH_TARGETS && viper.IsSet(key) {
+		targets, _ := readPushTargets()
+		return strings.TrimSpace(plural(len(targets), "push target"))
+	}
+
+	switch value := viper.Get(key).(type) {
+	case nil:
+		return constants.EMPTY
+	case map[string]any, []any:
+		data, _ := yaml.Marshal(value)
+		return strings.TrimSpace(string(data))
+	}
+
+	return configMask(key, viper.GetString(key))
+}
+
+// configMask hides the API key, so it is not shown on screen.
+func configMask(key string, value string) string {
+	if key == constants.PUSH_API_KEY && value != constants.EMPTY {
+		return "********"
+	}
+
+	return value
+}
+
+func configUnknownKey(key string) {
+	log.Fatalf("%s: Unknown setting[%s].  Use 'khronos config list' to see the settings.\n", color.RedString(constants.FATAL_NORMAL_CASE), key)
+	os.Exit(1)
+}
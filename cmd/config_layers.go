@@ -0,0 +1,278 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"khronos/constants"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configLayer is a .khronos.yaml file found in the current directory, or one
+// of its parents, whose settings are merged over the global configuration
+// file.  This lets a repository carry its own favorites and settings, e.g.,
+// its workspaces, how its tickets are found, and which push target its time
+// is pushed to.  Only the layerKeys settings are merged; ignored holds the
+// others the file sets.
+type configLayer struct {
+	path       string
+	settings   map[string]any
+	favorites  []Favorite
+	rules      []Rule
+	workspaces []Workspace
+	ignored    []string
+}
+
+// layerKeys holds the settings a per-directory file may set, with the nested
+// ones dotted.  They describe the project worked on there; a per-directory
+// file may pick one of the global file's push targets, but the push targets
+// themselves and their credentials, the database, and the git hook only come
+// from the global file, so a cloned repository cannot change them.
+var layerKeys = map[string]bool{
+	constants.FAVORITES:             true,
+	constants.PUSH_TARGET:           true,
+	constants.REQUIRE_NOTE:          true,
+	constants.ROUND_TO_MINUTES:      true,
+	constants.RULES:                 true,
+	constants.TICKET_BRANCH_PATTERN: true,
+	constants.TICKET_FROM_BRANCH:    true,
+	constants.TICKET_FROM_NOTE:      true,
+	constants.TICKET_NOTE_PATTERN:   true,
+	constants.TICKET_NOTE_PROJECTS:  true,
+	constants.WORKSPACES:            true,
+}
+
+// layerKeyNames returns the settings a per-directory file may set, sorted.
+func layerKeyNames() []string {
+	var names []string
+	for name := range layerKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// layerKeyParent reports whether any of the layerKeys settings is nested in
+// the dotted key, e.g., push.
+func layerKeyParent(key string) bool {
+	for name := range layerKeys {
+		if strings.HasPrefix(name, key+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// layerSettings returns the layerKeys settings in the mapping, whose dotted
+// key is prefix, appending the other settings to ignored.
+func layerSettings(mapping map[string]any, prefix string, ignored *[]string) map[string]any {
+	var settings map[string]any = map[string]any{}
+	for key, value := range mapping {
+		var name string = key
+		if prefix != constants.EMPTY {
+			name = prefix + "." + key
+		}
+
+		if nested, isMapping := value.(map[string]any); isMapping && !layerKeys[name] && layerKeyParent(name) {
+			if nested = layerSettings(nested, name, ignored); len(nested) > 0 {
+				settings[key] = nested
+			}
+		} else if layerKeys[name] {
+			settings[key] = value
+		} else {
+			*ignored = append(*ignored, name)
+		}
+	}
+
+	return settings
+}
+
+// configLayers holds the layers in the order they are merged, the outermost
+// directory first, so the innermost one wins.
+var configLayers []configLayer
+
+// findConfigLayers returns the .khronos.yaml files found walking up from dir
+// to the root, outermost first.  The global configuration file is left out,
+// since it is always read first.
+func findConfigLayers(dir string, global string) []string {
+	globalInfo, _ := os.Stat(global)
+
+	var paths []string
+	for {
+		var path string = filepath.Join(dir, constants.CONFIGURATION_FILE)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && (globalInfo == nil || !os.SameFile(info, globalInfo)) {
+			paths = append([]string{path}, paths...)
+		}
+
+		var parent string = filepath.Dir(dir)
+		if parent == dir {
+			return paths
+		}
+		dir = parent
+	}
+}
+
+// mergeConfigLayers reads the per-directory configuration files and merges
+// their settings over the global ones.  The favorites, rules, and workspaces
+// are not merged by viper, which would replace the global ones; loadFavorites,
+// loadRules, and loadWorkspaces append each layer's to the global ones.
+// Settings a per-directory file may not set are ignored, with a warning.
+func mergeConfigLayers() error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+
+	configLayers = nil
+	for _, path := range findConfigLayers(dir, viper.ConfigFileUsed()) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading configuration file[%s]. %s", path, err.Error())
+		}
+
+		var all map[string]any
+		var config Configuration
+		err = yaml.Unmarshal(data, &all)
+		if err == nil {
+			err = yaml.Unmarshal(data, &config)
+		}
+		if err != nil {
+			return fmt.Errorf("error unmarshaling configuration file[%s]. %s", path, err.Error())
+		}
+
+		var layer configLayer = configLayer{path: path, rules: config.Rules, workspaces: config.Workspaces}
+		layer.settings = layerSettings(all, constants.EMPTY, &layer.ignored)
+
+		if len(layer.ignored) > 0 {
+			sort.Strings(layer.ignored)
+			log.Printf("%s: Ignoring %s in the configuration file[%s].  Only %s can be set per directory.\n",
+				color.YellowString("Warning"), strings.Join(layer.ignored, ", "), path, strings.Join(layerKeyNames(), ", "))
+		}
+
+		for _, f := range config.Favorites {
+			f.Layer = path
+			layer.favorites = append(layer.favorites, f)
+		}
+
+		// The lists are left to their loaders, rather than replacing the
+		// global ones.
+		var settings map[string]any = map[string]any{}
+		for key, value := range layer.settings {
+			if !isLayerList(key) {
+				settings[key] = value
+			}
+		}
+		viper.MergeConfigMap(settings)
+
+		configLayers = append(configLayers, layer)
+	}
+
+	return nil
+}
+
+// isLayerList reports whether the setting is a list each layer adds to,
+// rather than replaces.
+func isLayerList(key string) bool {
+	return key == constants.FAVORITES || key == constants.RULES || key == constants.WORKSPACES
+}
+
+// listLength returns how many of the favorites, rules, or workspaces the layer
+// adds.
+func (l configLayer) listLength(key string) int {
+	switch key {
+	case constants.FAVORITES:
+		return len(l.favorites)
+	case constants.RULES:
+		return len(l.rules)
+	case constants.WORKSPACES:
+		return len(l.workspaces)
+	}
+
+	return 0
+}
+
+// configLayerOf returns the innermost layer that sets the dotted key, or nil
+// if no layer does.
+func configLayerOf(key string) *configLayer {
+	for i := len(configLayers) - 1; i >= 0; i-- {
+		if configLayers[i].has(key) {
+			return &configLayers[i]
+		}
+	}
+
+	return nil
+}
+
+// has reports whether the layer sets the dotted key.
+func (l configLayer) has(key string) bool {
+	var settings map[string]any = l.settings
+	var names []string = strings.Split(strings.ToLower(key), ".")
+	for i, name := range names {
+		value, found := settings[name]
+		if !found {
+			return false
+		}
+
+		if i == len(names)-1 {
+			return true
+		}
+
+		if settings, found = value.(map[string]any); !found {
+			return false
+		}
+	}
+
+	return false
+}
+
+// configLayerLabel returns how a layer is shown: its directory, with the home
+// directory shortened to ~.
+func configLayerLabel(path string) string {
+	var dir string = filepath.Dir(path)
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join("~", rel)
+		}
+	}
+
+	return dir
+}
@@ -0,0 +1,563 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"khronos/constants"
+	"khronos/internal/push"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configKind is the type of value a configuration key holds.
+type configKind int
+
+const (
+	configString configKind = iota
+	configBool
+	configInt
+	configMapping
+	configFavorites
+	configWorkspaces
+	configRules
+	configPushTargets
+)
+
+var configKindNames = map[configKind]string{
+	configString:      "a string",
+	configBool:        "true or false",
+	configInt:         "a whole number",
+	configMapping:     "a mapping",
+	configFavorites:   "a list of favorites",
+	configWorkspaces:  "a list of workspaces",
+	configRules:       "a list of rules",
+	configPushTargets: "a list of push targets",
+}
+
+// configSetting describes a configuration key: the type of its value and what
+// it is for.
+type configSetting struct {
+	kind        configKind
+	description string
+}
+
+// configSchema holds the configuration keys Khronos knows about, with nested
+// keys joined by a dot as viper does.
+var configSchema = map[string]configSetting{
+	constants.COMMIT_HOOK:                {configString, "What the git post-commit hook records a commit as, suggest or entry."},
+	constants.DATABASE_FILE:              {configString, "The database file used by Khronos."},
+	constants.DEBUG:                      {configBool, "Print debug information."},
+	constants.DISPLAY_BY_DAY_TOTALS:      {configBool, "Display day totals on the reports."},
+	constants.DISPLAY_HMS_ABBREVIATED:    {configBool, "Abbreviate hours, minutes, and seconds to h, m, and s."},
+	constants.DISPLAY_TIME_IN_24H_FORMAT: {configBool, "Display times in 24 hour format."},
+	constants.FAVORITE_ORDER:             {configString, "Order of the interactive favorite selector, config or smart."},
+	constants.FAVORITE_RECENT:            {configInt, "Number of recently used project+tasks shown below the favorites."},
+	constants.FAVORITES:                  {configFavorites, "The favorites, managed with the favorite command."},
+	"push":                               {configMapping, "Where entries are pushed to."},
+	constants.PUSH_API_KEY:               {configString, "API key, or token, used to push."},
+	constants.PUSH_TARGET:                {configString, "Name of the only push target entries are pushed to, e.g., in a repository's directory."},
+	constants.PUSH_TARGETS:               {configPushTargets, "Named servers entries are pushed to, each with its own credentials."},
+	constants.PUSH_TYPE:                  {configString, "Type of server pushed to, e.g., jira."},
+	constants.PUSH_URL:                   {configString, "URL of the server pushed to, e.g., https://acme.atlassian.net."},
+	constants.PUSH_USERNAME:              {configString, "Username used to push."},
+	"report":                             {configMapping, "Which reports are run."},
+	constants.REPORT_BY_DAY:              {configBool, "Run the by day report."},
+	constants.REPORT_BY_ENTRY:            {configBool, "Run the by entry report."},
+	constants.REPORT_BY_PROJECT:          {configBool, "Run the by project report."},
+	constants.REPORT_BY_TASK:             {configBool, "Run the by task report."},
+	constants.REQUIRE_NOTE:               {configBool, "Require a note for every entry."},
+	constants.ROUND_TO_MINUTES:           {configInt, "Number of minutes reports round to."},
+	constants.RULES:                      {configRules, "Rules classifying entries by project, task, note, weekday, and time."},
+	constants.SPLIT_WORK_FROM_BREAK_TIME: {configBool, "Split work and break time on the reports."},
+	constants.TICKET_BRANCH_PATTERN:      {configString, "Regular expression finding the ticket in a git branch's name."},
+	constants.TICKET_FROM_BRANCH:         {configBool, "Add the ticket in the current git branch's name to new entries."},
+	constants.TICKET_FROM_NOTE:           {configBool, "Add the ticket mentioned in the note to new entries."},
+	constants.TICKET_NOTE_PATTERN:        {configString, "Regular expression finding the tickets mentioned in a note."},
+	constants.TICKET_NOTE_PROJECTS:       {configString, "Jira project keys, e.g., ABC, ACME, the tickets found in notes must be from."},
+	constants.WEEK_START:                 {configString, "Day the week starts on, e.g., Sunday."},
+	constants.WORKSPACES:                 {configWorkspaces, "Rules mapping directories and git remotes to a default project."},
+}
+
+// pushTargetKeys holds the settings a push target may have.
+var pushTargetKeys = map[string]bool{
+	"name": true, "type": true, "url": true, "username": true, "api_key": true, "ticket_pattern": true,
+}
+
+// favoriteKeys holds the settings a favorite may have.
+var favoriteKeys = map[string]bool{
+	"favorite": true, "alias": true, "description": true, "ticket": true, "require_note": true,
+	"note": true, "tags": true, "properties": true, "duration": true,
+}
+
+// configProblem is a problem found in the configuration file, at the given
+// 1-based line.
+type configProblem struct {
+	Line    int
+	Message string
+}
+
+func (p configProblem) String() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+var yamlLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// validateConfigFile checks the configuration file: that it is valid YAML and
+// parses into the Configuration, that its keys are known and their values of
+// the right type, and that the favorites, week_start, favorite_order,
+// commit_hook, and push.type settings are valid.  The problems are returned
+// in line order.
+func validateConfigFile(path string) ([]configProblem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration file[%s]. %s", path, err.Error())
+	}
+
+	return validateConfig(data), nil
+}
+
+func validateConfig(data []byte) []configProblem {
+	return validateConfigData(data, true)
+}
+
+// validateLayerConfig checks a per-directory configuration file, which, unlike
+// the global one, need not have a push.type, and may only set the layerKeys
+// settings.
+func validateLayerConfig(data []byte) []configProblem {
+	return validateConfigData(data, false)
+}
+
+func validateConfigData(data []byte, global bool) []configProblem {
+	var v configValidator = configValidator{lines: make(map[int]bool)}
+
+	var doc yaml.Node
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		v.addError(err)
+		return v.sorted()
+	}
+
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		if global {
+			v.add(1, "the configuration file is empty")
+		}
+		return v.sorted()
+	}
+
+	var root *yaml.Node = doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		v.add(root.Line, "the configuration file must be a mapping of keys to values")
+		return v.sorted()
+	}
+
+	v.mapping(root, constants.EMPTY)
+
+	// A per-directory file may only set the settings describing the project
+	// worked on there.
+	if !global {
+		v.layerMapping(root, constants.EMPTY)
+	}
+
+	// Settings checked by every command when it starts.
+	var pushType *yaml.Node
+	if value := mappingValue(root, "push"); value != nil {
+		pushType = mappingValue(value, "type")
+	}
+
+	// The push targets are checked on their own, each taking the push.type
+	// only if it has no type of its own.
+	var pushTargets *yaml.Node
+	if value := mappingValue(root, "push"); value != nil {
+		pushTargets = mappingValue(value, "targets")
+	}
+	var hasTargets bool = pushTargets != nil && pushTargets.Kind == yaml.SequenceNode && len(pushTargets.Content) > 0
+
+	if pushType == nil && global && !hasTargets {
+		v.add(root.Line, fmt.Sprintf("%s is required and must be %s", constants.PUSH_TYPE, strings.Join(push.Types(), " or ")))
+	} else if pushType != nil && !push.IsType(pushType.Value) {
+		v.add(pushType.Line, fmt.Sprintf("invalid %s[%s], must be %s", constants.PUSH_TYPE, pushType.Value, strings.Join(push.Types(), " or ")))
+	}
+
+	if value := mappingValue(root, constants.WEEK_START); value != nil && value.Kind == yaml.ScalarNode {
+		if _, err := parseWeekday(value.Value); err != nil {
+			v.add(value.Line, fmt.Sprintf("invalid %s[%s], must be a day of the week such as Sunday", constants.WEEK_START, value.Value))
+		}
+	}
+
+	if value := mappingValue(root, constants.TICKET_BRANCH_PATTERN); value != nil && value.Kind == yaml.ScalarNode {
+		if _, err := regexp.Compile(value.Value); err != nil {
+			v.add(value.Line, fmt.Sprintf("invalid %s[%s]. %s", constants.TICKET_BRANCH_PATTERN, value.Value, err.Error()))
+		}
+	}
+
+	if value := mappingValue(root, constants.TICKET_NOTE_PATTERN); value != nil && value.Kind == yaml.ScalarNode {
+		if _, err := regexp.Compile(value.Value); err != nil {
+			v.add(value.Line, fmt.Sprintf("invalid %s[%s]. %s", constants.TICKET_NOTE_PATTERN, value.Value, err.Error()))
+		}
+	}
+
+	if value := mappingValue(root, constants.TICKET_NOTE_PROJECTS); value != nil && value.Kind == yaml.ScalarNode {
+		if _, err := parseTicketProjects(value.Value); err != nil {
+			v.add(value.Line, err.Error())
+		}
+	}
+
+	if value := mappingValue(root, constants.COMMIT_HOOK); value != nil && value.Kind == yaml.ScalarNode {
+		if !strings.EqualFold(value.Value, constants.COMMIT_HOOK_SUGGEST) && !strings.EqualFold(value.Value, constants.COMMIT_HOOK_ENTRY) {
+			v.add(value.Line, fmt.Sprintf("invalid %s[%s], must be %s or %s", constants.COMMIT_HOOK, value.Value,
+				constants.COMMIT_HOOK_SUGGEST, constants.COMMIT_HOOK_ENTRY))
+		}
+	}
+
+	if value := mappingValue(root, constants.FAVORITE_ORDER); value != nil && value.Kind == yaml.ScalarNode {
+		if !strings.EqualFold(value.Value, constants.FAVORITE_ORDER_CONFIG) && !strings.EqualFold(value.Value, constants.FAVORITE_ORDER_SMART) {
+			v.add(value.Line, fmt.Sprintf("invalid %s[%s], must be %s or %s", constants.FAVORITE_ORDER, value.Value,
+				constants.FAVORITE_ORDER_CONFIG, constants.FAVORITE_ORDER_SMART))
+		}
+	}
+
+	for _, key := range []string{constants.ROUND_TO_MINUTES, constants.FAVORITE_RECENT} {
+		if value := mappingValue(root, key); value != nil && value.Tag == "!!int" {
+			if n, _ := strconv.Atoi(value.Value); n < 0 {
+				v.add(value.Line, fmt.Sprintf("invalid %s[%s], must not be negative", key, value.Value))
+			}
+		}
+	}
+
+	// Finally, make sure it parses into the Configuration the commands use.
+	// Any type errors not already found above are reported here.
+	var config Configuration
+	if err := doc.Decode(&config); err != nil {
+		v.addError(err)
+	}
+
+	return v.sorted()
+}
+
+// configValidator collects the problems found.  lines holds the lines with a
+// problem, so a YAML error is not reported on a line that already has one.
+type configValidator struct {
+	problems []configProblem
+	lines    map[int]bool
+}
+
+func (v *configValidator) add(line int, message string) {
+	v.lines[line] = true
+	v.problems = append(v.problems, configProblem{Line: line, Message: message})
+}
+
+// addError adds a YAML error, which may hold several problems, each prefixed
+// with its line.  The problems on lines that already have one are left out,
+// since they were found by the checks with a clearer message.
+func (v *configValidator) addError(err error) {
+	var messages []string = []string{err.Error()}
+
+	var typeError *yaml.TypeError
+	if errors.As(err, &typeError) {
+		messages = typeError.Errors
+	}
+
+	for _, message := range messages {
+		var line int = 1
+		if match := yamlLineRegex.FindStringSubmatch(message); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+
+		if v.lines[line] {
+			continue
+		}
+
+		message = strings.TrimPrefix(message, "yaml: ")
+		message = strings.TrimSpace(yamlLineRegex.ReplaceAllString(message, ""))
+		v.add(line, strings.TrimPrefix(message, ": "))
+	}
+}
+
+func (v *configValidator) sorted() []configProblem {
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Line < v.problems[j].Line
+	})
+
+	return v.problems
+}
+
+// layerMapping reports the known settings of a per-directory file's mapping,
+// whose dotted key is prefix, that are not layerKeys settings.
+func (v *configValidator) layerMapping(mapping *yaml.Node, prefix string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		var key *yaml.Node = mapping.Content[i]
+		var value *yaml.Node = mapping.Content[i+1]
+
+		var name string = key.Value
+		if prefix != constants.EMPTY {
+			name = prefix + "." + key.Value
+		}
+
+		if _, known := configSchema[name]; !known || layerKeys[name] {
+			continue
+		}
+
+		if value.Kind == yaml.MappingNode && layerKeyParent(name) {
+			v.layerMapping(value, name)
+		} else {
+			v.add(key.Line, fmt.Sprintf("%s cannot be set in a per-directory file", name))
+		}
+	}
+}
+
+// mapping checks the keys of a mapping, and the type of their values, against
+// the schema.  prefix is the dotted key of the mapping.
+func (v *configValidator) mapping(mapping *yaml.Node, prefix string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		var key *yaml.Node = mapping.Content[i]
+		var value *yaml.Node = mapping.Content[i+1]
+
+		var name string = key.Value
+		if prefix != constants.EMPTY {
+			name = prefix + "." + key.Value
+		}
+
+		setting, known := configSchema[name]
+		if !known {
+			v.add(key.Line, fmt.Sprintf("unknown setting[%s]", name))
+			continue
+		}
+
+		if !configValueIs(value, setting.kind) {
+			v.add(value.Line, fmt.Sprintf("%s must be %s", name, configKindNames[setting.kind]))
+			continue
+		}
+
+		switch setting.kind {
+		case configMapping:
+			v.mapping(value, name)
+		case configFavorites:
+			v.favorites(value)
+		case configWorkspaces:
+			v.workspaces(value)
+		case configRules:
+			v.rules(value)
+		case configPushTargets:
+			v.pushTargets(value, mapping)
+		}
+	}
+}
+
+// favorites checks each favorite's settings, syntax, and alias.
+func (v *configValidator) favorites(sequence *yaml.Node) {
+	for i, node := range sequence.Content {
+		if node.Kind != yaml.MappingNode {
+			v.add(node.Line, fmt.Sprintf("favorite[%d] must be a mapping with at least a favorite setting", i+1))
+			continue
+		}
+
+		var valid bool = true
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if !favoriteKeys[node.Content[j].Value] {
+				v.add(node.Content[j].Line, fmt.Sprintf("unknown favorite setting[%s]", node.Content[j].Value))
+				valid = false
+			}
+		}
+
+		var f Favorite
+		if err := node.Decode(&f); err != nil || !valid {
+			// The type errors are reported when decoding the Configuration.
+			continue
+		}
+
+		if err := validateFavorite(f); err != nil {
+			v.add(node.Line, fmt.Sprintf("favorite[%d] %s", i+1, err.Error()))
+		} else if err := checkFavoriteAlias(sequence, f.Alias, i); err != nil {
+			v.add(node.Line, fmt.Sprintf("favorite[%d] %s", i+1, err.Error()))
+		}
+	}
+}
+
+// workspaces checks each workspace's settings, that it has a directory or
+// remote to match, and that its globs and ticket pattern are valid.
+func (v *configValidator) workspaces(sequence *yaml.Node) {
+	for i, node := range sequence.Content {
+		if node.Kind != yaml.MappingNode {
+			v.add(node.Line, fmt.Sprintf("workspace[%d] must be a mapping with at least a directory or remote setting", i+1))
+			continue
+		}
+
+		var valid bool = true
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if !workspaceKeys[node.Content[j].Value] {
+				v.add(node.Content[j].Line, fmt.Sprintf("unknown workspace setting[%s]", node.Content[j].Value))
+				valid = false
+			}
+		}
+
+		var w Workspace
+		if err := node.Decode(&w); err != nil || !valid {
+			// The type errors are reported when decoding the Configuration.
+			continue
+		}
+
+		if w.Directory == constants.EMPTY && w.Remote == constants.EMPTY {
+			v.add(node.Line, fmt.Sprintf("workspace[%d] needs a directory or remote to match", i+1))
+		} else if _, err := filepath.Match(w.Directory, constants.EMPTY); err != nil {
+			v.add(node.Line, fmt.Sprintf("workspace[%d] has an invalid directory[%s]", i+1, w.Directory))
+		} else if _, err := path.Match(w.Remote, constants.EMPTY); err != nil {
+			v.add(node.Line, fmt.Sprintf("workspace[%d] has an invalid remote[%s]", i+1, w.Remote))
+		} else if w.Task != constants.EMPTY && w.Project == constants.EMPTY {
+			v.add(node.Line, fmt.Sprintf("workspace[%d] has a task but no project", i+1))
+		} else if _, err := w.ticketRegex(); err != nil {
+			v.add(node.Line, fmt.Sprintf("workspace[%d] has an %s", i+1, err.Error()))
+		}
+	}
+}
+
+// pushTargets checks each push target's settings, its name, and that it can
+// be pushed to, and that the push mapping's target, if any, is one of them.  A
+// target without a type is of the push mapping's type.
+func (v *configValidator) pushTargets(sequence *yaml.Node, pushMapping *yaml.Node) {
+	var names map[string]bool = make(map[string]bool)
+	for i, node := range sequence.Content {
+		if node.Kind != yaml.MappingNode {
+			v.add(node.Line, fmt.Sprintf("push target[%d] must be a mapping with at least a name setting", i+1))
+			continue
+		}
+
+		var valid bool = true
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if !pushTargetKeys[node.Content[j].Value] {
+				v.add(node.Content[j].Line, fmt.Sprintf("unknown push target setting[%s]", node.Content[j].Value))
+				valid = false
+			}
+		}
+
+		var target push.Target
+		if err := node.Decode(&target); err != nil || !valid {
+			// The type errors are reported when decoding the Configuration.
+			continue
+		}
+
+		if target.Type == constants.EMPTY {
+			if pushType := mappingValue(pushMapping, "type"); pushType != nil {
+				target.Type = pushType.Value
+			}
+		}
+
+		if err := checkPushTargetName(target.Name, i+1, names); err != nil {
+			v.add(node.Line, err.Error())
+		} else if _, err := compilePushTarget(target); err != nil {
+			v.add(node.Line, err.Error())
+		}
+	}
+
+	if chosen := mappingValue(pushMapping, "target"); chosen != nil && chosen.Kind == yaml.ScalarNode && !names[strings.ToLower(chosen.Value)] {
+		v.add(chosen.Line, fmt.Sprintf("%s[%s] is not one of the push targets", constants.PUSH_TARGET, chosen.Value))
+	}
+}
+
+// rules checks each rule's settings, those of its match and set, and that it
+// is valid.
+func (v *configValidator) rules(sequence *yaml.Node) {
+	for i, node := range sequence.Content {
+		if node.Kind != yaml.MappingNode {
+			v.add(node.Line, fmt.Sprintf("rule[%d] must be a mapping with match and set settings", i+1))
+			continue
+		}
+
+		var valid bool = true
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			var key, value *yaml.Node = node.Content[j], node.Content[j+1]
+			if !ruleKeys[key.Value] {
+				v.add(key.Line, fmt.Sprintf("unknown rule setting[%s]", key.Value))
+				valid = false
+				continue
+			}
+
+			var keys map[string]bool = ruleMatchKeys
+			if key.Value == "set" {
+				keys = ruleSetKeys
+			} else if key.Value != "match" {
+				continue
+			}
+
+			if value.Kind != yaml.MappingNode {
+				v.add(value.Line, fmt.Sprintf("rule[%d] %s must be a mapping", i+1, key.Value))
+				valid = false
+				continue
+			}
+			for k := 0; k+1 < len(value.Content); k += 2 {
+				if !keys[value.Content[k].Value] {
+					v.add(value.Content[k].Line, fmt.Sprintf("unknown rule %s setting[%s]", key.Value, value.Content[k].Value))
+					valid = false
+				}
+			}
+		}
+
+		var r Rule
+		if err := node.Decode(&r); err != nil || !valid {
+			// The type errors are reported when decoding the Configuration.
+			continue
+		}
+
+		if _, err := compileRule(r, i+1); err != nil {
+			v.add(node.Line, fmt.Sprintf("%s %s", ruleLabel(r, i+1), err.Error()))
+		}
+	}
+}
+
+// configValueIs reports whether the YAML value is of the given kind.  An
+// empty value, e.g., `database_file:`, is allowed for any kind.
+func configValueIs(value *yaml.Node, kind configKind) bool {
+	if value.Tag == "!!null" {
+		return true
+	}
+
+	switch kind {
+	case configBool:
+		return value.Tag == "!!bool"
+	case configInt:
+		return value.Tag == "!!int"
+	case configMapping:
+		return value.Kind == yaml.MappingNode
+	case configFavorites, configWorkspaces, configRules, configPushTargets:
+		return value.Kind == yaml.SequenceNode
+	}
+
+	return value.Kind == yaml.ScalarNode
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"khronos/constants"
+	"log"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// doctorCmd represents the doctor command.
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.DOCTOR_SHORT_DESCRIPTION,
+	Long:  constants.DOCTOR_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runDoctor(cmd)
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringP(constants.FLAG_FROM, constants.EMPTY, constants.EMPTY, "Check the entries from the given day, in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	doctorCmd.Flags().StringP(constants.FLAG_TO, constants.EMPTY, constants.EMPTY, "Check the entries up to the given day, in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	doctorCmd.Flags().BoolP(constants.DRY_RUN, constants.EMPTY, false, "Show the tickets that would be added without adding them.")
+
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command) {
+	dryRun, _ := cmd.Flags().GetBool(constants.DRY_RUN)
+	from, _ := cmd.Flags().GetString(constants.FLAG_FROM)
+	to, _ := cmd.Flags().GetString(constants.FLAG_TO)
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	defer db.Close()
+
+	if db.GetCountEntries() == 0 {
+		log.Printf("%s\n", color.YellowString("No entries found. Nothing changed."))
+		return
+	}
+
+	// Every entry is checked, unless told otherwise.
+	var start carbon.Carbon = *carbon.Parse(db.GetFirstEntry().EntryDatetime)
+	var end carbon.Carbon = *carbon.Now()
+	if !stringUtils.IsEmpty(from) {
+		start = parseBulkDate(from)
+	}
+	if !stringUtils.IsEmpty(to) {
+		end = parseBulkDate(to)
+	}
+	start = *start.StartOfDay()
+	end = *end.EndOfDay()
+
+	var pattern = noteTicketRegex(viper.GetString(constants.TICKET_NOTE_PATTERN))
+	var projects map[string]bool = noteTicketProjects()
+
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.DATE_TIME_NORMAL_CASE, "Project+Task", constants.NOTE_NORMAL_CASE, constants.TICKET_NORMAL_CASE})
+
+	var changed []models.Entry
+	for _, entry := range db.GetEntriesForToday(start, end) {
+		if entry.Project == constants.HELLO || isBreakOrUntracked(entry.Project) || !stringUtils.IsBlank(entry.GetTicketAsString()) {
+			continue
+		}
+
+		ticket, found := noteTicket(entry.Note, pattern, projects)
+		if !found {
+			continue
+		}
+
+		var e models.Entry = models.NewEntry(entry.Uid, entry.Project, entry.Note, entry.EntryDatetime)
+		for _, p := range entry.Properties {
+			if p.Name != constants.PUSHED {
+				e.AddEntryProperty(p.Name, p.Value)
+			}
+		}
+		e.AddEntryProperty(constants.TICKET, ticket)
+		e.AddEntryProperty(constants.PUSHED, constants.EMPTY)
+
+		t.AppendRow(table.Row{carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local), entryProjectTask(entry), entry.Note, ticket})
+		changed = append(changed, e)
+	}
+
+	if len(changed) == 0 {
+		log.Printf("%s\n", color.GreenString("No entry's note mentions a ticket it does not have. Nothing changed."))
+		return
+	}
+
+	log.Printf("These entries' notes mention a ticket they do not have\n\n%s\n\n", t.Render())
+
+	if dryRun {
+		log.Printf("%s\n", color.YellowString("Dry run, %d entries NOT changed.", len(changed)))
+		return
+	}
+
+	log.Printf("%s: Once added, the tickets are pushed by the next 'report --%s'.\n", color.HiBlueString(constants.INFO_NORMAL_CASE), constants.FLAG_PUSH)
+
+	yesNo := yesNoPrompt("Add the tickets to these %d entries?", len(changed))
+	if yesNo {
+		// All the entries are written in a single transaction.
+		db.UpdateEntriesProperties(changed)
+		log.Printf("%s\n", color.GreenString("Tickets added."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Tickets NOT added."))
+	}
+}
@@ -31,10 +31,17 @@ POSSIBILITY OF SUCH DAMAGE.
 package cmd
 
 import (
+	"errors"
 	"khronos/constants"
 	"log"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -54,11 +61,104 @@ func init() {
 }
 
 func runEdit(_ *cobra.Command, _ []string) {
-	log.Printf("Opening the %s file in your default editor...\n", viper.ConfigFileUsed())
-	exePath := "c:\\windows\\system32\\notepad.exe"
-	cmd := exec.Command(exePath, viper.ConfigFileUsed())
-	err := cmd.Start()
+	var path string = viper.ConfigFileUsed()
+	var line int = 0
+
+	// Keep re-opening the file, at the first problem, until it is valid or the
+	// user gives up.
+	for {
+		log.Printf("Opening the %s file in your editor...\n", path)
+		err := openInEditor(path, line)
+		if err != nil {
+			log.Fatalf("%s: Unable to open the configuration file in your editor. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		problems, err := validateConfigFile(path)
+		if err != nil {
+			log.Fatalf("%s: %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		if len(problems) == 0 {
+			log.Printf("%s\n", color.GreenString("The configuration file is valid."))
+			return
+		}
+
+		log.Printf("\nThe configuration file has the following problems\n")
+		for _, problem := range problems {
+			log.Printf("    %s\n", problem)
+		}
+
+		if !yesNoPrompt("\nRe-open the file at line %d?", problems[0].Line) {
+			log.Printf("%s: The configuration file was left with problems, the next command may fail.\n", color.YellowString("Warning"))
+			os.Exit(1)
+		}
+
+		line = problems[0].Line
+	}
+}
+
+// openInEditor opens the file in the user's editor, at the given line if it
+// is not 0 and the editor supports it, and waits for the editor to exit.
+func openInEditor(path string, line int) error {
+	editor, err := findEditor()
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+
+	var args []string = append(editor[1:], editorFileArgs(editor[0], path, line)...)
+	cmd := exec.Command(editor[0], args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// findEditor returns the user's editor and its arguments from $VISUAL or
+// $EDITOR, falling back to the operating system's default text editor.
+func findEditor() ([]string, error) {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.Fields(os.Getenv(name)); len(editor) > 0 {
+			return editor, nil
+		}
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"notepad.exe"}, nil
+	case "darwin":
+		// Open in TextEdit and wait for it to quit.
+		return []string{"open", "-W", "-t"}, nil
+	}
+
+	for _, editor := range []string{"sensible-editor", "editor", "nano", "vi"} {
+		if _, err := exec.LookPath(editor); err == nil {
+			return []string{editor}, nil
+		}
+	}
+
+	return nil, errors.New("no editor was found, please set $VISUAL or $EDITOR")
+}
+
+// editorFileArgs returns the arguments that open the file at the given line
+// for the editor, since each editor has its own way of doing so.
+func editorFileArgs(editor string, path string, line int) []string {
+	if line <= 0 {
+		return []string{path}
 	}
+
+	var name string = strings.TrimSuffix(strings.ToLower(filepath.Base(editor)), ".exe")
+	switch name {
+	case "vi", "vim", "nvim", "gvim", "view", "nano", "pico", "emacs", "emacsclient", "micro", "kak", "joe", "jed", "ne", "mg":
+		return []string{"+" + strconv.Itoa(line), path}
+	case "code", "code-insiders", "codium", "cursor":
+		return []string{"--goto", path + ":" + strconv.Itoa(line)}
+	case "subl", "sublime_text", "zed", "hx", "helix":
+		return []string{path + ":" + strconv.Itoa(line)}
+	case "notepad++":
+		return []string{"-n" + strconv.Itoa(line), path}
+	}
+
+	return []string{path}
 }
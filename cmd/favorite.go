@@ -0,0 +1,348 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"khronos/constants"
+	"khronos/internal/database"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// favoriteCmd represents the favorite command.
+var favoriteCmd = &cobra.Command{
+	Use:   "favorite",
+	Short: constants.FAVORITE_SHORT_DESCRIPTION,
+	Long:  constants.FAVORITE_LONG_DESCRIPTION,
+}
+
+var favoriteListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "List the favorites",
+	Run: func(cmd *cobra.Command, args []string) {
+		showFavorites()
+	},
+}
+
+var favoriteAddCmd = &cobra.Command{
+	Use:   "add project+task",
+	Args:  cobra.ExactArgs(1),
+	Short: "Add a favorite to the end of the list",
+	Run: func(cmd *cobra.Command, args []string) {
+		runFavoriteAdd(cmd, args)
+	},
+}
+
+var favoriteEditCmd = &cobra.Command{
+	Use:   "edit number [project+task]",
+	Args:  cobra.RangeArgs(1, 2),
+	Short: "Edit a favorite, changing only what is given",
+	Run: func(cmd *cobra.Command, args []string) {
+		runFavoriteEdit(cmd, args)
+	},
+}
+
+var favoriteRemoveCmd = &cobra.Command{
+	Use:   "remove number",
+	Args:  cobra.ExactArgs(1),
+	Short: "Remove a favorite",
+	Run: func(cmd *cobra.Command, args []string) {
+		runFavoriteRemove(cmd, args)
+	},
+}
+
+var favoriteMoveCmd = &cobra.Command{
+	Use:   "move number to-number",
+	Args:  cobra.ExactArgs(2),
+	Short: "Move a favorite to another position in the list",
+	Run: func(cmd *cobra.Command, args []string) {
+		runFavoriteMove(cmd, args)
+	},
+}
+
+var favoriteSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Args:  cobra.ExactArgs(0),
+	Short: "Suggest favorites from the project+tasks you use often",
+	Long:  "Suggest, and offer to add, the project+tasks you have used most often and most recently that are not yet favorites, along with the ticket they were usually added with.",
+	Run: func(cmd *cobra.Command, args []string) {
+		runFavoriteSuggest(cmd, args)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{favoriteAddCmd, favoriteEditCmd} {
+		c.Flags().StringP(constants.FLAG_DESCRIPTION, constants.EMPTY, constants.EMPTY, "Description shown alongside the favorite.")
+		c.Flags().StringP(constants.FLAG_TICKET, constants.EMPTY, constants.EMPTY, "Ticket, e.g., ABC-123, added to entries for the favorite.")
+		c.Flags().BoolP(constants.FLAG_REQUIRE_NOTE, constants.EMPTY, false, "Require a note when adding the favorite.")
+		c.Flags().StringP(constants.FLAG_ALIAS, constants.EMPTY, constants.EMPTY, "Alias that can be used in place of the project+task, e.g., 'standup'.")
+		c.Flags().StringP(constants.NOTE, constants.EMPTY, constants.EMPTY, "Default note, which may use {date}, {weekday}, and {ticket}.")
+		c.Flags().StringArrayP(constants.FLAG_TAG, constants.EMPTY, []string{}, "Default tag. Specify once per tag.")
+		c.Flags().StringArrayP(constants.FLAG_PROPERTY, constants.EMPTY, []string{}, "Default property as name=value. Specify once per property.")
+		c.Flags().StringP(constants.FLAG_DURATION, constants.EMPTY, constants.EMPTY, "Default duration, e.g., '15m', used as if --for was entered.")
+	}
+
+	favoriteSuggestCmd.Flags().IntP(constants.FLAG_DAYS, constants.EMPTY, favoriteUsageDays, "Number of days of entries to look at.")
+	favoriteSuggestCmd.Flags().IntP(constants.FLAG_LIMIT, constants.EMPTY, 10, "Maximum number of suggestions.")
+	favoriteSuggestCmd.Flags().IntP(constants.FLAG_MIN_USES, constants.EMPTY, 3, "Minimum number of entries a project+task needs to be suggested.")
+
+	favoriteCmd.AddCommand(favoriteListCmd, favoriteAddCmd, favoriteEditCmd, favoriteRemoveCmd, favoriteMoveCmd, favoriteSuggestCmd)
+	rootCmd.AddCommand(favoriteCmd)
+}
+
+func runFavoriteAdd(cmd *cobra.Command, args []string) {
+	var f Favorite = Favorite{Favorite: args[0]}
+	applyFavoriteFlags(cmd, &f)
+
+	favoriteFatalIfError(addFavorite(viper.ConfigFileUsed(), f))
+	log.Printf("%s\n", color.GreenString("Favorite[%d] %s added.", len(loadFavorites())-layerFavoriteCount(), f.Favorite))
+}
+
+func runFavoriteEdit(cmd *cobra.Command, args []string) {
+	var index int = globalFavoriteIndex(args[0])
+	var f Favorite = getFavorite(index)
+
+	if len(args) > 1 {
+		f.Favorite = args[1]
+	}
+
+	applyFavoriteFlags(cmd, &f)
+
+	favoriteFatalIfError(editFavorite(viper.ConfigFileUsed(), index, f))
+	log.Printf("%s\n", color.GreenString("Favorite[%d] %s edited.", index+1, f.Favorite))
+}
+
+func runFavoriteRemove(_ *cobra.Command, args []string) {
+	var index int = globalFavoriteIndex(args[0])
+	var f Favorite = getFavorite(index)
+
+	yesNo := yesNoPrompt("Remove favorite[%d] %s?", index+1, f.Favorite)
+	if yesNo {
+		favoriteFatalIfError(removeFavorite(viper.ConfigFileUsed(), index))
+		log.Printf("%s\n", color.GreenString("Favorite removed."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Favorite NOT removed."))
+	}
+}
+
+func runFavoriteMove(_ *cobra.Command, args []string) {
+	var from int = globalFavoriteIndex(args[0])
+	var to int = globalFavoriteIndex(args[1])
+
+	favoriteFatalIfError(moveFavorite(viper.ConfigFileUsed(), from, to))
+	showFavoritesTable(loadFavorites())
+}
+
+func runFavoriteSuggest(cmd *cobra.Command, _ []string) {
+	days, _ := cmd.Flags().GetInt(constants.FLAG_DAYS)
+	limit, _ := cmd.Flags().GetInt(constants.FLAG_LIMIT)
+	minUses, _ := cmd.Flags().GetInt(constants.FLAG_MIN_USES)
+
+	if days < 1 || limit < 1 {
+		log.Fatalf("%s: --%s and --%s must be >= 1.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.FLAG_DAYS, constants.FLAG_LIMIT)
+		os.Exit(1)
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	var favs []Favorite = loadFavorites()
+
+	// Suggest the most used project+tasks that are not favorites yet.
+	var suggestions []projectTaskSummary
+	for _, summary := range projectTaskHistory(db, days) {
+		if summary.Uses >= minUses && !isFavorite(favs, summary.ProjectTask) {
+			suggestions = append(suggestions, summary)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	if len(suggestions) == 0 {
+		log.Printf("%s\n", color.YellowString("No suggestions; every project+task used at least %d times in the last %d days is already a favorite.", minUses, days))
+		return
+	}
+
+	var t table.Writer = table.NewWriter()
+	SetReportTableStyle(t)
+	t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.TICKET_NORMAL_CASE, "Uses", "Last Used"})
+	for i, suggestion := range suggestions {
+		t.AppendRow(table.Row{
+			i + 1,
+			suggestion.ProjectTask,
+			suggestion.Ticket,
+			suggestion.Uses,
+			carbon.Parse(suggestion.LastUsed).SetTimezone(carbon.Local).Format(constants.CARBON_DATE_FORMAT),
+		})
+	}
+
+	log.Printf("Project+tasks used in the last %d days that are not favorites:\n\n", days)
+	log.Println(t.Render())
+
+	var added int
+	for _, suggestion := range suggestions {
+		yesNo := yesNoPrompt("Add %s as a favorite?", suggestion.ProjectTask)
+		if !yesNo {
+			continue
+		}
+
+		var f Favorite = Favorite{Favorite: suggestion.ProjectTask, Ticket: suggestion.Ticket}
+		favoriteFatalIfError(addFavorite(viper.ConfigFileUsed(), f))
+		added++
+	}
+
+	if added > 0 {
+		log.Printf("%s\n", color.GreenString("%d favorite(s) added.", added))
+	} else {
+		log.Printf("%s\n", color.YellowString("No favorites added."))
+	}
+}
+
+// applyFavoriteFlags sets the fields of the favorite whose flags were entered.
+func applyFavoriteFlags(cmd *cobra.Command, f *Favorite) {
+	if cmd.Flags().Changed(constants.FLAG_ALIAS) {
+		f.Alias, _ = cmd.Flags().GetString(constants.FLAG_ALIAS)
+	}
+
+	if cmd.Flags().Changed(constants.FLAG_DESCRIPTION) {
+		f.Description, _ = cmd.Flags().GetString(constants.FLAG_DESCRIPTION)
+	}
+
+	if cmd.Flags().Changed(constants.FLAG_TICKET) {
+		f.Ticket, _ = cmd.Flags().GetString(constants.FLAG_TICKET)
+	}
+
+	if cmd.Flags().Changed(constants.FLAG_REQUIRE_NOTE) {
+		f.RequireNote, _ = cmd.Flags().GetBool(constants.FLAG_REQUIRE_NOTE)
+	}
+
+	if cmd.Flags().Changed(constants.NOTE) {
+		f.Note, _ = cmd.Flags().GetString(constants.NOTE)
+	}
+
+	if cmd.Flags().Changed(constants.FLAG_DURATION) {
+		f.Duration, _ = cmd.Flags().GetString(constants.FLAG_DURATION)
+	}
+
+	// The tags and properties given replace the existing ones.  An empty
+	// --tag or --property clears them.
+	if cmd.Flags().Changed(constants.FLAG_TAG) {
+		tags, _ := cmd.Flags().GetStringArray(constants.FLAG_TAG)
+		f.Tags = nil
+		for _, tag := range tags {
+			if !stringUtils.IsEmpty(tag) {
+				f.Tags = append(f.Tags, strings.TrimPrefix(tag, constants.TAG_PREFIX))
+			}
+		}
+	}
+
+	if cmd.Flags().Changed(constants.FLAG_PROPERTY) {
+		properties, _ := cmd.Flags().GetStringArray(constants.FLAG_PROPERTY)
+		f.Properties = nil
+		for _, property := range properties {
+			if stringUtils.IsEmpty(property) {
+				continue
+			}
+
+			name, value, found := strings.Cut(property, "=")
+			if !found || stringUtils.IsBlank(name) {
+				log.Fatalf("%s: Malformed property[%s], expected name=value.\n", color.RedString(constants.FATAL_NORMAL_CASE), property)
+				os.Exit(1)
+			}
+
+			if f.Properties == nil {
+				f.Properties = make(map[string]string)
+			}
+			f.Properties[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+}
+
+// favoriteIndex converts the 1-based favorite number the user sees into a
+// 0-based index.
+func favoriteIndex(number string) int {
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 {
+		log.Fatalf("%s: Invalid favorite number[%s].  Favorite must be >= 1.\n", color.RedString(constants.FATAL_NORMAL_CASE), number)
+		os.Exit(1)
+	}
+
+	return n - 1
+}
+
+// globalFavoriteIndex returns the 0-based index of the favorite, which must
+// be one of the global configuration file's, as the favorite command only
+// changes that file.
+func globalFavoriteIndex(number string) int {
+	var index int = favoriteIndex(number)
+
+	var favs []Favorite = loadFavorites()
+	if index < len(favs) && favs[index].Layer != constants.EMPTY {
+		log.Fatalf("%s: Favorite[%d] %s is from the configuration file[%s].  Edit that file to change it.\n",
+			color.RedString(constants.FATAL_NORMAL_CASE), index+1, favs[index].Favorite, favs[index].Layer)
+		os.Exit(1)
+	}
+
+	return index
+}
+
+// layerFavoriteCount returns the number of favorites from the per-directory
+// configuration files.
+func layerFavoriteCount() int {
+	var count int
+	for _, layer := range configLayers {
+		count += len(layer.favorites)
+	}
+
+	return count
+}
+
+func favoriteFatalIfError(err error) {
+	if err != nil {
+		log.Fatalf("%s: %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+}
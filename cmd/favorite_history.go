@@ -0,0 +1,144 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"khronos/constants"
+	"khronos/internal/database"
+
+	"github.com/dromara/carbon/v2"
+)
+
+// favoriteUsageDays is how far back entries count towards a project+task's
+// history by default, and favoriteUsageHalfLife how quickly a use stops
+// counting.
+const favoriteUsageDays = 90
+const favoriteUsageHalfLife = 14.0
+
+// projectTaskSummary sums up how a project+task was used.
+type projectTaskSummary struct {
+	ProjectTask string
+	Uses        int
+	Score       float64 // how recently and frequently it was used
+	LastUsed    string
+	Ticket      string // the ticket used most often, if any
+}
+
+// projectTaskHistory sums up the project+tasks used over the last number of
+// days, most recently used first.  Each use adds 1 to the score when it is new
+// and half as much every favoriteUsageHalfLife days after that.
+func projectTaskHistory(db *database.Database, days int) []projectTaskSummary {
+	var now *carbon.Carbon = carbon.Now()
+	var summaries map[string]*projectTaskSummary = make(map[string]*projectTaskSummary)
+	var tickets map[string]map[string]int = make(map[string]map[string]int)
+
+	for _, use := range db.GetProjectTaskUses(*now.Copy().SubDays(days), *now) {
+		// Hello, break, and untracked time are not favorites.
+		if use.Project == constants.HELLO || use.Project == constants.BREAK || use.Project == constants.UNTRACKED {
+			continue
+		}
+
+		var projectTask string = use.Project + constants.TASK_DELIMITER + use.Task
+		var key string = strings.ToLower(projectTask)
+		summary, found := summaries[key]
+		if !found {
+			summary = &projectTaskSummary{ProjectTask: projectTask}
+			summaries[key] = summary
+			tickets[key] = make(map[string]int)
+		}
+
+		var age float64 = float64(carbon.Parse(use.EntryDatetime).DiffInSeconds(now)) / constants.SECONDS_PER_DAY
+		summary.Uses++
+		summary.Score += math.Pow(0.5, max(age, 0)/favoriteUsageHalfLife)
+		summary.LastUsed = use.EntryDatetime
+
+		if ticketRegex.MatchString(use.Ticket) {
+			tickets[key][use.Ticket]++
+			if tickets[key][use.Ticket] > tickets[key][summary.Ticket] {
+				summary.Ticket = use.Ticket
+			}
+		}
+	}
+
+	var history []projectTaskSummary
+	for _, summary := range summaries {
+		history = append(history, *summary)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].LastUsed > history[j].LastUsed
+	})
+
+	return history
+}
+
+// favoriteUsage returns the score of each project+task in the history, keyed
+// by lower case project+task.
+func favoriteUsage(history []projectTaskSummary) map[string]float64 {
+	var usage map[string]float64 = make(map[string]float64)
+	for _, summary := range history {
+		usage[strings.ToLower(summary.ProjectTask)] = summary.Score
+	}
+
+	return usage
+}
+
+// isFavorite reports whether the project+task is one of the favorites.
+func isFavorite(favs []Favorite, projectTask string) bool {
+	for _, f := range favs {
+		if strings.EqualFold(strings.TrimSpace(f.Favorite), projectTask) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recentFavorites returns up to count of the most recently used project+tasks
+// that are not favorites, as favorites carrying their usual ticket.
+func recentFavorites(history []projectTaskSummary, favs []Favorite, count int) []Favorite {
+	var recent []Favorite
+	for _, summary := range history {
+		if len(recent) >= count {
+			break
+		}
+
+		if !isFavorite(favs, summary.ProjectTask) {
+			recent = append(recent, Favorite{Favorite: summary.ProjectTask, Ticket: summary.Ticket})
+		}
+	}
+
+	return recent
+}
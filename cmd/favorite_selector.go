@@ -32,11 +32,11 @@ package cmd
 
 import (
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
 	"khronos/constants"
-	"khronos/internal/jira"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -50,100 +50,401 @@ import (
 // cursor, jump to a row by number, and select. The highlight is done by
 // go-pretty's SetRowPainter, so the box-drawing stays intact rather than being
 // overlaid with ANSI after the fact.
+//
+// Typing "/" filters the favorites; the cursor moves over the rows that are
+// shown, in visible, while the "#" column always refers to the favorite's
+// position in the configuration file.
+//
+// Below the favorites is a section of recently used project+tasks that are
+// not favorites.  Row indexes run through the favorites and then the recent
+// ones; see favorite.
 type favoriteSelectorModel struct {
-	favs             []Favorite
-	descriptionFound bool
-	ticketFound      bool
-
-	cursor   int
+	favs    []Favorite
+	recent  []Favorite
+	columns favoriteColumns
+
+	// Filtering and ordering.  usage scores how recently and frequently each
+	// project+task was used; smart orders the favorites by it rather than in
+	// config order.  visible holds the indexes of the favorites shown, and
+	// highlights their matched characters.
+	usage      map[string]float64
+	smart      bool
+	filtering  bool
+	query      string
+	visible    []int
+	highlights [][][]int
+
+	cursor   int    // index into visible
 	numBuf   string // accumulates typed digits for jump-to-row
-	chosen   int    // selected index, or -1 if none
+	chosen   int    // selected row index, or -1 if none
 	quitting bool
 
 	caption string // action text, e.g. "Select a favorite to add"
 	config  string // config file path, shown in the help line
+
+	// Scoping to the workspace of the current directory.  scoped is whether
+	// only the rows in scope are shown; w toggles it.
+	scope  *favoriteScope
+	scoped bool
+
+	// In place editing.  mode is browsing, editing the form, or confirming a
+	// delete.  formIndex is the favorite being edited, or -1 when adding.
+	mode      favoriteSelectorMode
+	form      [favoriteFormFieldCount]string
+	formField int
+	formIndex int
+	formNote  bool
+	status    string // result of the last add/edit/delete, or an error
+}
+
+// favoriteScope narrows the selector to the favorites, and recent
+// project+tasks, of a workspace.
+type favoriteScope struct {
+	name    string // shown in the help line, e.g., acme
+	inScope func(Favorite) bool
+}
+
+type favoriteSelectorMode int
+
+const (
+	favoriteModeBrowse favoriteSelectorMode = iota
+	favoriteModeForm
+	favoriteModeConfirmDelete
+)
+
+// The text fields of the add/edit form, followed by the require note toggle.
+const (
+	favoriteFormProjectTask = iota
+	favoriteFormAlias
+	favoriteFormDescription
+	favoriteFormTicket
+	favoriteFormFieldCount
+)
+
+var favoriteFormLabels = [favoriteFormFieldCount]string{constants.PROJECT_TASK, constants.ALIAS, constants.DESCRIPTION, constants.TICKET}
+
+func newFavoriteSelectorModel(caption, config string, favs []Favorite, recent []Favorite, usage map[string]float64, smart bool) favoriteSelectorModel {
+	// Drop any recent project+task that has since been made a favorite.
+	var stillRecent []Favorite
+	for _, f := range recent {
+		if !isFavorite(favs, f.Favorite) {
+			stillRecent = append(stillRecent, f)
+		}
+	}
+
+	var m favoriteSelectorModel = favoriteSelectorModel{
+		favs:      favs,
+		recent:    stillRecent,
+		columns:   newFavoriteColumns(append(append([]Favorite{}, favs...), stillRecent...)),
+		usage:     usage,
+		smart:     smart,
+		cursor:    0,
+		chosen:    -1,
+		caption:   caption,
+		config:    config,
+		formIndex: -1,
+	}
+
+	return m.applyFilter(-1)
+}
+
+// favorite returns the favorite or recent project+task of the row index.
+func (m favoriteSelectorModel) favorite(index int) Favorite {
+	if index >= len(m.favs) {
+		return m.recent[index-len(m.favs)]
+	}
+
+	return m.favs[index]
+}
+
+// isRecent reports whether the row index is in the recent section.
+func (m favoriteSelectorModel) isRecent(index int) bool {
+	return index >= len(m.favs)
+}
+
+// layered reports whether the row index is a favorite from a per-directory
+// configuration file, which the selector does not change.
+func (m favoriteSelectorModel) layered(index int) bool {
+	return index >= 0 && !m.isRecent(index) && m.favs[index].Layer != constants.EMPTY
+}
+
+// layeredStatus tells the user to edit the file the favorite under the cursor
+// is from.
+func (m favoriteSelectorModel) layeredStatus() favoriteSelectorModel {
+	m.status = m.favs[m.current()].Favorite + " is from " + m.favs[m.current()].Layer + "; edit that file to change it."
+	return m
 }
 
-func newFavoriteSelectorModel(caption, config string, favs []Favorite) favoriteSelectorModel {
-	var descriptionFound, ticketFound bool
-	for _, f := range favs {
-		if len(f.Ticket) > 0 {
-			ticketFound = true
+// order returns the indexes of the favorites in config order or, in smart
+// order, most used first.
+func (m favoriteSelectorModel) order() []int {
+	var order []int = make([]int, len(m.favs))
+	for i := range order {
+		order[i] = i
+	}
+
+	if m.smart {
+		sort.SliceStable(order, func(i, j int) bool {
+			return m.favoriteUsage(order[i]) > m.favoriteUsage(order[j])
+		})
+	}
+
+	return order
+}
+
+func (m favoriteSelectorModel) favoriteUsage(index int) float64 {
+	return m.usage[strings.ToLower(m.favs[index].Favorite)]
+}
+
+// applyFilter re-filters the favorites and the recent section with the
+// current query and order, putting the cursor on the given row if it is still
+// shown, or on the first row otherwise.
+func (m favoriteSelectorModel) applyFilter(current int) favoriteSelectorModel {
+	var fields func(int) []string = func(i int) []string {
+		return favoriteSearchFields(m.favorite(i))
+	}
+
+	var order []int
+	for _, index := range m.order() {
+		if m.inScope(index) {
+			order = append(order, index)
+		}
+	}
+
+	var recent []int
+	for i := range m.recent {
+		if m.inScope(len(m.favs) + i) {
+			recent = append(recent, len(m.favs)+i)
 		}
-		if len(f.Description) > 0 {
-			descriptionFound = true
+	}
+
+	// Filter each section on its own so the recent ones stay below the
+	// favorites.
+	m.visible, m.highlights = filterRows(m.query, order, fields)
+	recentVisible, recentHighlights := filterRows(m.query, recent, fields)
+	m.visible = append(m.visible, recentVisible...)
+	m.highlights = append(m.highlights, recentHighlights...)
+
+	m.cursor = 0
+	for i, index := range m.visible {
+		if index == current {
+			m.cursor = i
 		}
 	}
 
-	return favoriteSelectorModel{
-		favs:             favs,
-		descriptionFound: descriptionFound,
-		ticketFound:      ticketFound,
-		cursor:           0,
-		chosen:           -1,
-		caption:          caption,
-		config:           config,
+	return m
+}
+
+// inScope reports whether the row index is shown given the scope.
+func (m favoriteSelectorModel) inScope(index int) bool {
+	return !m.scoped || m.scope.inScope(m.favorite(index))
+}
+
+// current returns the row index under the cursor, or -1 if no rows are
+// shown.
+func (m favoriteSelectorModel) current() int {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return -1
+	}
+
+	return m.visible[m.cursor]
+}
+
+// reload re-reads the favorites after an add, edit, or delete, keeping the
+// cursor on the given favorite.
+func (m favoriteSelectorModel) reload(current int) favoriteSelectorModel {
+	var reloaded favoriteSelectorModel = newFavoriteSelectorModel(m.caption, m.config, loadFavorites(), m.recent, m.usage, m.smart)
+	reloaded.status = m.status
+	reloaded.query = m.query
+	reloaded.scope = m.scope
+	reloaded.scoped = m.scoped
+	return reloaded.applyFilter(max(0, min(current, len(reloaded.favs)-1)))
+}
+
+// openForm switches to the add/edit form.  index is the favorite to edit, or
+// -1 to add a new one.  Editing a recent project+task adds it as a favorite.
+func (m favoriteSelectorModel) openForm(index int) favoriteSelectorModel {
+	m.mode = favoriteModeForm
+	m.formIndex = index
+	m.formField = favoriteFormProjectTask
+	m.form = [favoriteFormFieldCount]string{}
+	m.formNote = false
+	m.status = ""
+
+	if index >= 0 {
+		var f Favorite = m.favorite(index)
+		if m.isRecent(index) {
+			m.formIndex = -1
+		}
+		m.form = [favoriteFormFieldCount]string{f.Favorite, f.Alias, f.Description, f.Ticket}
+		m.formNote = f.RequireNote
+	}
+
+	return m
+}
+
+// submitForm validates and writes the form to the configuration file.  On
+// error, the form stays open with the error shown.
+func (m favoriteSelectorModel) submitForm() favoriteSelectorModel {
+	// When editing, start from the favorite so the settings the form does not
+	// show, e.g., its default note and tags, are kept.
+	var f Favorite
+	if m.formIndex >= 0 {
+		f = m.favs[m.formIndex]
+	}
+
+	f.Favorite = strings.TrimSpace(m.form[favoriteFormProjectTask])
+	f.Alias = strings.TrimSpace(m.form[favoriteFormAlias])
+	f.Description = strings.TrimSpace(m.form[favoriteFormDescription])
+	f.Ticket = strings.TrimSpace(m.form[favoriteFormTicket])
+	f.RequireNote = m.formNote
+
+	var err error
+	var cursor int
+	if m.formIndex < 0 {
+		err = addFavorite(m.config, f)
+		cursor = len(m.favs)
+	} else {
+		err = editFavorite(m.config, m.formIndex, f)
+		cursor = m.formIndex
+	}
+
+	if err != nil {
+		m.status = "Error: " + err.Error()
+		return m
 	}
+
+	m.mode = favoriteModeBrowse
+	m.status = f.Favorite + " saved."
+	return m.reload(cursor)
+}
+
+// updateForm handles a key while the add/edit form is open.  Tab and the
+// arrow keys move between fields, space toggles require note, enter saves,
+// and esc cancels.
+func (m favoriteSelectorModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		m.mode = favoriteModeBrowse
+		m.status = ""
+		return m, nil
+
+	case tea.KeyEnter:
+		return m.submitForm(), nil
+
+	case tea.KeyTab, tea.KeyDown:
+		m.formField = (m.formField + 1) % (favoriteFormFieldCount + 1)
+		return m, nil
+
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.formField = (m.formField + favoriteFormFieldCount) % (favoriteFormFieldCount + 1)
+		return m, nil
+
+	case tea.KeyBackspace:
+		if m.formField < favoriteFormFieldCount {
+			var runes []rune = []rune(m.form[m.formField])
+			if len(runes) > 0 {
+				m.form[m.formField] = string(runes[:len(runes)-1])
+			}
+		}
+		return m, nil
+
+	case tea.KeySpace:
+		if m.formField == favoriteFormFieldCount {
+			m.formNote = !m.formNote
+		} else {
+			m.form[m.formField] += " "
+		}
+		return m, nil
+
+	case tea.KeyRunes:
+		if m.formField < favoriteFormFieldCount {
+			m.form[m.formField] += string(msg.Runes)
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// formView renders the add/edit form below the table.
+func (m favoriteSelectorModel) formView() string {
+	var b strings.Builder
+
+	if m.formIndex < 0 {
+		b.WriteString("Add favorite\n")
+	} else {
+		b.WriteString("Edit favorite " + strconv.Itoa(m.formIndex+1) + "\n")
+	}
+
+	for i, label := range favoriteFormLabels {
+		var marker string = "  "
+		if i == m.formField {
+			marker = "> "
+		}
+		b.WriteString(marker + label + ": " + m.form[i])
+		if i == m.formField {
+			b.WriteString("_")
+		}
+		b.WriteString("\n")
+	}
+
+	var marker string = "  "
+	if m.formField == favoriteFormFieldCount {
+		marker = "> "
+	}
+	b.WriteString(marker + constants.REQUIRE_NOTE + ": " + strconv.FormatBool(m.formNote) + "\n")
+
+	return b.String()
 }
 
 // renderTable builds the go-pretty table string with the cursor row highlighted.
-// This mirrors showFavoritesTable's column logic so the look matches exactly.
+// The columns come from favoriteColumns so the look matches showFavoritesTable.
 func (m favoriteSelectorModel) renderTable() string {
 	t := table.NewWriter()
 
-	// Match the column layout used by showFavoritesTable.
-	var requiredNoteColumn int
-	if m.descriptionFound && m.ticketFound {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.DESCRIPTION, constants.URL, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 5
-	} else if m.descriptionFound {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.DESCRIPTION, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 4
-	} else if m.ticketFound {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.URL, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 4
-	} else {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 3
-	}
+	t.AppendHeader(m.columns.header())
 
 	style := table.StyleDefault
 	style.Format.Header = text.FormatUpper
 	t.SetStyle(style)
 
 	t.SetColumnConfigs([]table.ColumnConfig{
-		{Number: 1, WidthMin: 3, WidthMax: 3},
-		{Number: requiredNoteColumn, WidthMin: 13, WidthMax: 13},
+		{Number: 1, WidthMin: 3, WidthMax: 3, Align: text.AlignRight},
+		{Number: m.columns.requireNoteColumn(), WidthMin: 13, WidthMax: 13},
 	})
 
-	for i, f := range m.favs {
-		// Display number is 1-based; stored as an int so the row painter can
-		// match against the cursor (cursor+1). The selector still returns the
-		// 0-based slice index to callers.
-		num := i + 1
-		if m.descriptionFound && m.ticketFound {
-			t.AppendRow(table.Row{num, f.Favorite, f.Description, jira.FormatJiraUrl(jira.JiraBrowseTicketUrl, f.Ticket), f.RequireNote})
-		} else if m.descriptionFound {
-			t.AppendRow(table.Row{num, f.Favorite, f.Description, f.RequireNote})
-		} else if m.ticketFound {
-			t.AppendRow(table.Row{num, f.Favorite, jira.FormatJiraUrl(jira.JiraBrowseTicketUrl, f.Ticket), f.RequireNote})
-		} else {
-			t.AppendRow(table.Row{num, f.Favorite, f.RequireNote})
+	for i, index := range m.visible {
+		// Display number is 1-based, matching `show --favorites` and the
+		// --favorite flag.  Recent project+tasks are numbered R1, R2, etc.,
+		// below a separator.
+		if !m.isRecent(index) {
+			t.AppendRow(m.columns.row(index+1, m.favs[index], m.highlights[i]))
+			continue
+		}
+
+		if i == 0 || !m.isRecent(m.visible[i-1]) {
+			t.AppendSeparator()
 		}
+
+		var row table.Row = m.columns.row(0, m.favorite(index), m.highlights[i])
+		row[0] = "R" + strconv.Itoa(index-len(m.favs)+1)
+		t.AppendRow(row)
 	}
 
-	// Highlight the cursor row. RowPainter receives the row and is invoked per
-	// row before render; we color the row whose number matches cursor+1 (the
-	// "#" column is 1-based while the cursor is 0-based).
+	// Highlight the cursor row. The painter is given each row's 1-based
+	// position in the table, which matches the 0-based cursor plus one.
 	cursor := m.cursor
-	t.SetRowPainter(func(row table.Row) text.Colors {
-		if len(row) > 0 {
-			if num, ok := row[0].(int); ok && num == cursor+1 {
-				return text.Colors{text.BgBlue, text.FgHiWhite}
-			}
+	t.SetRowPainter(table.RowPainterWithAttributes(func(row table.Row, attr table.RowAttributes) text.Colors {
+		if attr.Number == cursor+1 {
+			return text.Colors{text.BgBlue, text.FgHiWhite}
 		}
 		return text.Colors{}
-	})
+	}))
 
 	return t.Render()
 }
@@ -153,8 +454,78 @@ func (m favoriteSelectorModel) Init() tea.Cmd { return nil }
 func (m favoriteSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.mode == favoriteModeForm {
+			return m.updateForm(msg)
+		}
+
+		if m.mode == favoriteModeConfirmDelete {
+			m.mode = favoriteModeBrowse
+			if msg.String() == "y" {
+				var index int = m.current()
+				var removed string = m.favs[index].Favorite
+				if err := removeFavorite(m.config, index); err != nil {
+					m.status = "Error: " + err.Error()
+					return m, nil
+				}
+				m.status = removed + " deleted."
+				return m.reload(index), nil
+			}
+			m.status = ""
+			return m, nil
+		}
+
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
 		switch msg.String() {
-		case "ctrl+c", "q", "esc":
+		case "/":
+			m.filtering = true
+			m.numBuf = ""
+			return m, nil
+
+		case "o":
+			m.smart = !m.smart
+			return m.applyFilter(m.current()), nil
+
+		case "w":
+			if m.scope != nil {
+				m.scoped = !m.scoped
+				return m.applyFilter(m.current()), nil
+			}
+			return m, nil
+
+		case "a":
+			return m.openForm(-1), nil
+
+		case "e":
+			if m.layered(m.current()) {
+				return m.layeredStatus(), nil
+			}
+			if m.current() >= 0 {
+				return m.openForm(m.current()), nil
+			}
+			return m, nil
+
+		case "d":
+			if m.layered(m.current()) {
+				return m.layeredStatus(), nil
+			}
+			if m.current() >= 0 && !m.isRecent(m.current()) {
+				m.mode = favoriteModeConfirmDelete
+			}
+			return m, nil
+
+		case "esc":
+			// The first esc clears the filter; the next one cancels.
+			if m.query != "" {
+				m.query = ""
+				return m.applyFilter(m.current()), nil
+			}
+			m.quitting = true
+			return m, tea.Quit
+
+		case "ctrl+c", "q":
 			m.quitting = true
 			return m, tea.Quit
 
@@ -165,24 +536,29 @@ func (m favoriteSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "down", "j":
-			if m.cursor < len(m.favs)-1 {
+			if m.cursor < len(m.visible)-1 {
 				m.cursor++
 			}
 			return m, nil
 
 		case "enter":
 			if m.numBuf != "" {
-				// The user types the 1-based number they see; convert to the
-				// 0-based cursor index.
-				if n, err := strconv.Atoi(m.numBuf); err == nil {
-					if n >= 1 && n <= len(m.favs) {
-						m.cursor = n - 1
+				// The user types the 1-based number they see; move the cursor
+				// to that favorite if it is shown.
+				if n, err := strconv.Atoi(m.numBuf); err == nil && n <= len(m.favs) {
+					for i, index := range m.visible {
+						if index == n-1 {
+							m.cursor = i
+						}
 					}
 				}
 				m.numBuf = ""
 				return m, nil
 			}
-			m.chosen = m.cursor
+			if m.current() < 0 {
+				return m, nil
+			}
+			m.chosen = m.current()
 			m.quitting = true
 			return m, tea.Quit
 
@@ -201,6 +577,45 @@ func (m favoriteSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateFilter handles a key while the filter is being typed.  The table
+// narrows as the query changes; enter keeps the filter and goes back to
+// browsing, and esc clears it.
+func (m favoriteSelectorModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		m.filtering = false
+		m.query = ""
+		return m.applyFilter(m.current()), nil
+
+	case tea.KeyEnter:
+		m.filtering = false
+		return m, nil
+
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+
+	case tea.KeyDown:
+		if m.cursor < len(m.visible)-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+
+	if query, ok := editFilterQuery(m.query, msg); ok {
+		m.query = query
+		return m.applyFilter(-1), nil
+	}
+
+	return m, nil
+}
+
 func (m favoriteSelectorModel) View() string {
 	if m.quitting {
 		return ""
@@ -209,6 +624,15 @@ func (m favoriteSelectorModel) View() string {
 	var b strings.Builder
 	b.WriteString(m.renderTable())
 	b.WriteString("\n")
+	if m.filtering || m.query != "" {
+		b.WriteString(filterLine(m.filtering, m.query, len(m.visible)))
+	}
+	if m.mode == favoriteModeForm {
+		b.WriteString(m.formView())
+	}
+	if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
 	b.WriteString(m.helpLine())
 	b.WriteString("\n")
 	return b.String()
@@ -217,9 +641,28 @@ func (m favoriteSelectorModel) View() string {
 func (m favoriteSelectorModel) helpLine() string {
 	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 
-	keys := "up/down: navigate - enter: select - type a number + enter: jump - q/esc: cancel"
-	if m.numBuf != "" {
+	order := "config order"
+	if m.smart {
+		order = "smart order"
+	}
+
+	if m.scope != nil && m.scoped {
+		order += " - w: all favorites"
+	} else if m.scope != nil {
+		order += " - w: " + m.scope.name + " favorites"
+	}
+
+	keys := "up/down: navigate - enter: select - type a number + enter: jump - /: filter - o: " + order + " - a/e/d: add/edit/delete - q/esc: cancel"
+	if m.mode == favoriteModeForm {
+		keys = "tab/up/down: next field - space: toggle require note - enter: save - esc: cancel"
+	} else if m.mode == favoriteModeConfirmDelete {
+		keys = "delete favorite " + strconv.Itoa(m.current()+1) + " " + m.favs[m.current()].Favorite + "? y: delete - any other key: cancel"
+	} else if m.filtering {
+		keys = "type to filter - up/down: navigate - enter: done - esc: clear"
+	} else if m.numBuf != "" {
 		keys = "jump to row: " + m.numBuf + "  (enter to go, backspace to edit)"
+	} else if m.query != "" {
+		keys = "up/down: navigate - enter: select - /: filter - esc: clear filter - o: " + order + " - a/e/d: add/edit/delete - q: cancel"
 	}
 
 	// First line: the action caption plus the config file path. Second line:
@@ -241,23 +684,48 @@ func (m favoriteSelectorModel) helpLine() string {
 }
 
 // selectFavorite launches the interactive favorites selector and returns the
-// chosen index along with ok=true. On cancel it returns (-1, false). The
-// caption and config path are shown in the help line below the table.
-func selectFavorite(caption, config string, favs []Favorite) (int, bool, error) {
-	m := newFavoriteSelectorModel(caption, config, favs)
+// chosen favorite, or recently used project+task, along with ok=true. On
+// cancel it returns ok=false. The caption and config path are shown in the
+// help line below the table.  recent are the recently used project+tasks
+// shown below the favorites, and usage and smart set up the smart order; see
+// projectTaskHistory.  scope, if not nil, starts the selector showing only the
+// workspace's rows, and the cursor starts on the preselect project+task.
+func selectFavorite(caption, config string, favs []Favorite, recent []Favorite, usage map[string]float64, smart bool, scope *favoriteScope, preselect string) (Favorite, bool, error) {
+	requireInteractive("selecting a favorite is required; use project+task or --favorite instead", constants.EXIT_SELECTION_REQUIRED)
+
+	m := newFavoriteSelectorModel(caption, config, favs, recent, usage, smart)
+
+	// Start scoped to the workspace, unless none of the rows are in it.
+	if scope != nil {
+		m.scope = scope
+		m.scoped = true
+		m = m.applyFilter(-1)
+		if len(m.visible) == 0 {
+			m.scoped = false
+		}
+	}
+
+	var current int = -1
+	for i := 0; i < len(m.favs)+len(m.recent); i++ {
+		if preselect != constants.EMPTY && strings.EqualFold(m.favorite(i).Favorite, preselect) {
+			current = i
+			break
+		}
+	}
+	m = m.applyFilter(current)
 
 	// Inline (no alt-screen): renders in normal terminal flow.
 	p := tea.NewProgram(m)
 	final, err := p.Run()
 	if err != nil {
-		return -1, false, err
+		return Favorite{}, false, err
 	}
 
 	fm, ok := final.(favoriteSelectorModel)
 	if !ok || fm.chosen < 0 {
-		return -1, false, nil
+		return Favorite{}, false, nil
 	}
-	return fm.chosen, true, nil
+	return fm.favorite(fm.chosen), true, nil
 }
 
 // interactiveTerminal reports whether stdin/stdout are a real terminal.
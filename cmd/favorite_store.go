@@ -0,0 +1,418 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"khronos/constants"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"gopkg.in/yaml.v3"
+)
+
+// The favorites are edited as yaml.v3 nodes, rather than by unmarshaling into
+// a Configuration and marshaling it back, so the comments, ordering, and any
+// settings Khronos does not know about survive the round trip.
+
+// readConfigDocument reads and parses the configuration file into a document
+// node.  An empty file yields a document with an empty mapping.
+func readConfigDocument(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration file[%s]. %s", path, err.Error())
+	}
+
+	var doc yaml.Node
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling configuration file[%s]. %s", path, err.Error())
+	}
+
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("configuration file[%s] is not a YAML mapping", path)
+	}
+
+	return &doc, nil
+}
+
+// writeConfigDocument writes the document node back to the configuration file.
+// It writes a temporary file next to it first and renames it into place, so a
+// failure never leaves a half written configuration behind.  A symlinked
+// configuration file, e.g., from a dotfile manager, is written through to its
+// target, and the file keeps its mode.
+func writeConfigDocument(path string, doc *yaml.Node) error {
+	data, err := encodeConfigDocument(doc)
+	if err != nil {
+		return fmt.Errorf("error marshaling configuration file[%s]. %s", path, err.Error())
+	}
+
+	var target string = path
+	var mode os.FileMode = 0600
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		target = resolved
+		info, err := os.Stat(target)
+		if err != nil {
+			return fmt.Errorf("error writing configuration file[%s]. %s", path, err.Error())
+		}
+		mode = info.Mode().Perm()
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("error writing configuration file[%s]. %s", path, err.Error())
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(target), filepath.Base(target)+".*")
+	if err != nil {
+		return fmt.Errorf("error writing configuration file[%s]. %s", path, err.Error())
+	}
+	defer os.Remove(temp.Name())
+
+	_, err = temp.Write(data)
+	if err == nil {
+		err = temp.Chmod(mode)
+	}
+	if err == nil {
+		err = temp.Close()
+	} else {
+		temp.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(temp.Name(), target)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error writing configuration file[%s]. %s", path, err.Error())
+	}
+
+	return nil
+}
+
+// encodeConfigDocument encodes the document node as the configuration file
+// is written.
+func encodeConfigDocument(doc *yaml.Node) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	err := encoder.Encode(doc)
+	if err != nil {
+		return nil, err
+	}
+	encoder.Close()
+
+	return buffer.Bytes(), nil
+}
+
+// mappingValue returns the value node for the given key of a mapping node, or
+// nil if the key is not present.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setMappingValue sets the given key of a mapping node to a scalar value,
+// adding the key at the end if it is not already present.  An empty value
+// removes the key.
+func setMappingValue(mapping *yaml.Node, key string, value string, tag string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			if value == constants.EMPTY {
+				mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			} else {
+				mapping.Content[i+1].Kind = yaml.ScalarNode
+				mapping.Content[i+1].Tag = tag
+				mapping.Content[i+1].Value = value
+				mapping.Content[i+1].Style = 0
+			}
+			return
+		}
+	}
+
+	if value != constants.EMPTY {
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value})
+	}
+}
+
+// setMappingNode sets the given key of a mapping node to a node, adding the
+// key at the end if it is not already present.  A nil node removes the key.
+func setMappingNode(mapping *yaml.Node, key string, node *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			if node == nil {
+				mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			} else {
+				mapping.Content[i+1] = node
+			}
+			return
+		}
+	}
+
+	if node != nil {
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, node)
+	}
+}
+
+// favoritesSequence returns the sequence node holding the favorites, creating
+// it if the configuration does not have one yet.
+func favoritesSequence(doc *yaml.Node) *yaml.Node {
+	var root *yaml.Node = doc.Content[0]
+
+	var sequence *yaml.Node = mappingValue(root, constants.FAVORITES)
+	if sequence == nil {
+		sequence = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: constants.FAVORITES}, sequence)
+	} else if sequence.Kind != yaml.SequenceNode {
+		// e.g., 'favorites:' with nothing after it.
+		*sequence = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", HeadComment: sequence.HeadComment, LineComment: sequence.LineComment}
+	}
+
+	return sequence
+}
+
+// applyFavorite writes the fields of a favorite onto a favorite mapping node.
+// Empty fields are removed so the file stays as terse as the user wrote it.
+func applyFavorite(mapping *yaml.Node, f Favorite) {
+	setMappingValue(mapping, "favorite", f.Favorite, "!!str")
+	setMappingValue(mapping, "alias", f.Alias, "!!str")
+	setMappingValue(mapping, "description", f.Description, "!!str")
+	setMappingValue(mapping, "ticket", f.Ticket, "!!str")
+	if f.RequireNote {
+		setMappingValue(mapping, "require_note", strconv.FormatBool(f.RequireNote), "!!bool")
+	} else {
+		setMappingValue(mapping, "require_note", constants.EMPTY, "!!bool")
+	}
+	setMappingValue(mapping, "note", f.Note, "!!str")
+
+	if len(f.Tags) > 0 {
+		var tags *yaml.Node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+		for _, tag := range f.Tags {
+			tags.Content = append(tags.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag})
+		}
+		setMappingNode(mapping, "tags", tags)
+	} else {
+		setMappingNode(mapping, "tags", nil)
+	}
+
+	if len(f.Properties) > 0 {
+		var names []string = make([]string, 0, len(f.Properties))
+		for name := range f.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var properties *yaml.Node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, name := range names {
+			setMappingValue(properties, name, f.Properties[name], "!!str")
+		}
+		setMappingNode(mapping, "properties", properties)
+	} else {
+		setMappingNode(mapping, "properties", nil)
+	}
+
+	setMappingValue(mapping, "duration", f.Duration, "!!str")
+}
+
+// validateFavorite checks the project+task and ticket of a favorite.
+func validateFavorite(f Favorite) error {
+	_, _, err := parseProjectTask(f.Favorite)
+	if err != nil {
+		return err
+	}
+
+	if !stringUtils.IsEmpty(f.Ticket) && !ticketRegex.MatchString(f.Ticket) {
+		return fmt.Errorf("malformed ticket[%s], expected a key such as ABC-123", f.Ticket)
+	}
+
+	if strings.ContainsAny(f.Alias, constants.TASK_DELIMITER+" \t") {
+		return fmt.Errorf("malformed alias[%s], an alias cannot contain '%s' or spaces", f.Alias, constants.TASK_DELIMITER)
+	}
+
+	for _, tag := range f.Tags {
+		if stringUtils.IsBlank(tag) || strings.ContainsAny(tag, " \t") {
+			return fmt.Errorf("malformed tag[%s], a tag cannot be empty or contain spaces", tag)
+		}
+	}
+
+	for name := range f.Properties {
+		for _, reserved := range []string{constants.TASK, constants.TICKET, constants.PUSHED, constants.TAG} {
+			if strings.EqualFold(name, reserved) {
+				return fmt.Errorf("property[%s] is reserved, use the favorite's own setting instead", name)
+			}
+		}
+	}
+
+	if !stringUtils.IsEmpty(f.Duration) {
+		duration, err := time.ParseDuration(f.Duration)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("malformed duration[%s], expected a positive duration such as '15m' or '1h30m'", f.Duration)
+		}
+	}
+
+	return nil
+}
+
+// checkFavoriteAlias makes sure no other favorite, apart from the one at the
+// 0-based skip index, already uses the alias.
+func checkFavoriteAlias(sequence *yaml.Node, alias string, skip int) error {
+	if stringUtils.IsEmpty(alias) {
+		return nil
+	}
+
+	for i, node := range sequence.Content {
+		var other Favorite
+		if i == skip || node.Decode(&other) != nil {
+			continue
+		}
+
+		if strings.EqualFold(other.Alias, alias) {
+			return fmt.Errorf("alias[%s] is already used by favorite[%d] %s", alias, i+1, other.Favorite)
+		}
+	}
+
+	return nil
+}
+
+// updateFavorites reads the configuration file, lets change modify the
+// favorites sequence, and writes the file back.
+func updateFavorites(path string, change func(sequence *yaml.Node) error) error {
+	doc, err := readConfigDocument(path)
+	if err != nil {
+		return err
+	}
+
+	err = change(favoritesSequence(doc))
+	if err != nil {
+		return err
+	}
+
+	return writeConfigDocument(path, doc)
+}
+
+// checkFavoriteIndex makes sure a 0-based index refers to a favorite.
+func checkFavoriteIndex(sequence *yaml.Node, index int) error {
+	if index < 0 || index >= len(sequence.Content) {
+		return fmt.Errorf("favorite[%d] not found, there are %d favorites", index+1, len(sequence.Content))
+	}
+
+	return nil
+}
+
+// addFavorite appends a favorite to the configuration file.
+func addFavorite(path string, f Favorite) error {
+	err := validateFavorite(f)
+	if err != nil {
+		return err
+	}
+
+	return updateFavorites(path, func(sequence *yaml.Node) error {
+		err := checkFavoriteAlias(sequence, f.Alias, -1)
+		if err != nil {
+			return err
+		}
+
+		var mapping *yaml.Node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		applyFavorite(mapping, f)
+		sequence.Content = append(sequence.Content, mapping)
+		return nil
+	})
+}
+
+// editFavorite replaces the fields of the favorite at the 0-based index,
+// keeping any comments and unknown keys on it.
+func editFavorite(path string, index int, f Favorite) error {
+	err := validateFavorite(f)
+	if err != nil {
+		return err
+	}
+
+	return updateFavorites(path, func(sequence *yaml.Node) error {
+		err := checkFavoriteIndex(sequence, index)
+		if err == nil {
+			err = checkFavoriteAlias(sequence, f.Alias, index)
+		}
+		if err != nil {
+			return err
+		}
+
+		applyFavorite(sequence.Content[index], f)
+		return nil
+	})
+}
+
+// removeFavorite removes the favorite at the 0-based index.
+func removeFavorite(path string, index int) error {
+	return updateFavorites(path, func(sequence *yaml.Node) error {
+		err := checkFavoriteIndex(sequence, index)
+		if err != nil {
+			return err
+		}
+
+		sequence.Content = append(sequence.Content[:index], sequence.Content[index+1:]...)
+		return nil
+	})
+}
+
+// moveFavorite moves the favorite at the 0-based from index to the 0-based to
+// index, shifting the favorites in between.
+func moveFavorite(path string, from int, to int) error {
+	return updateFavorites(path, func(sequence *yaml.Node) error {
+		err := checkFavoriteIndex(sequence, from)
+		if err == nil {
+			err = checkFavoriteIndex(sequence, to)
+		}
+		if err != nil {
+			return err
+		}
+
+		var node *yaml.Node = sequence.Content[from]
+		sequence.Content = append(sequence.Content[:from], sequence.Content[from+1:]...)
+		sequence.Content = append(sequence.Content[:to], append([]*yaml.Node{node}, sequence.Content[to:]...)...)
+		return nil
+	})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// The git repository of the current directory is read straight from its .git
+// directory, rather than by running git, so it works without git installed
+// and costs nothing when the directory is not a repository.
+
+// findGitDir returns the git directory of the repository dir is in, walking up
+// to the root, or false if it is not in one.  A .git file, as used by work
+// trees and submodules, points to the git directory.
+func findGitDir(dir string) (string, bool) {
+	for {
+		var path string = filepath.Join(dir, ".git")
+		info, err := os.Stat(path)
+		if err == nil && info.IsDir() {
+			return path, true
+		}
+
+		if err == nil {
+			data, err := os.ReadFile(path)
+			if err == nil && strings.HasPrefix(string(data), "gitdir:") {
+				var gitDir string = strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir:"))
+				if !filepath.IsAbs(gitDir) {
+					gitDir = filepath.Join(dir, gitDir)
+				}
+				return gitDir, true
+			}
+		}
+
+		var parent string = filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// gitCommonDir returns the directory holding the repository's config, which
+// a work tree shares with the main repository.
+func gitCommonDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+
+	var commonDir string = strings.TrimSpace(string(data))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+
+	return commonDir
+}
+
+// gitRemoteURLs returns the URLs of the repository's remotes, in the order
+// they appear in its config.
+func gitRemoteURLs(gitDir string) []string {
+	file, err := os.Open(filepath.Join(gitCommonDir(gitDir), "config"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var urls []string
+	var inRemote bool
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line string = strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inRemote = strings.HasPrefix(line, "[remote ")
+			continue
+		}
+
+		name, value, found := strings.Cut(line, "=")
+		if inRemote && found && strings.TrimSpace(name) == "url" {
+			urls = append(urls, strings.Trim(strings.TrimSpace(value), `"`))
+		}
+	}
+
+	return urls
+}
+
+// gitBranch returns the branch checked out in the repository, or false if
+// HEAD is detached.
+func gitBranch(gitDir string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", false
+	}
+
+	ref, found := strings.CutPrefix(strings.TrimSpace(string(data)), "ref: refs/heads/")
+	return ref, found
+}
+
+// branchTicket returns the ticket found in a branch name with the pattern.
+// If the pattern has a group, the ticket is what the first group matches,
+// otherwise it is the whole match.
+func branchTicket(branch string, pattern *regexp.Regexp) (string, bool) {
+	var match []string = pattern.FindStringSubmatch(branch)
+	if match == nil {
+		return "", false
+	}
+
+	if len(match) > 1 {
+		return match[1], match[1] != ""
+	}
+
+	return match[0], true
+}
+
+// normalizeRemoteURL reduces a git remote URL to host/path, so the https and
+// ssh forms of the same repository compare equal, e.g., both
+// git@github.com:acme/api.git and https://github.com/acme/api become
+// github.com/acme/api.
+func normalizeRemoteURL(url string) string {
+	url = strings.TrimSpace(url)
+	if scheme, rest, found := strings.Cut(url, "://"); found && !strings.Contains(scheme, "/") {
+		url = rest
+	} else if host, path, found := strings.Cut(url, ":"); found && !strings.Contains(host, "/") {
+		// The scp like form, user@host:path.
+		url = host + "/" + path
+	}
+
+	// Drop any user, and password, before the host.
+	if at := strings.Index(url, "@"); at >= 0 && at < strings.Index(url+"/", "/") {
+		url = url[at+1:]
+	}
+
+	// Drop any port, e.g., ssh://git@host:2222/path.
+	if host, path, found := strings.Cut(url, "/"); found {
+		if name, port, found := strings.Cut(host, ":"); found && strings.Trim(port, "0123456789") == "" {
+			url = name + "/" + path
+		}
+	}
+
+	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	return strings.ToLower(url)
+}
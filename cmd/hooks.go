@@ -0,0 +1,369 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"khronos/constants"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// The hook is a block of lines, between these markers, in the repository's
+// post-commit hook, so it can live alongside whatever else the hook does and
+// be removed again without touching the rest.
+const hookBeginMarker = "# >>> khronos >>>"
+const hookEndMarker = "# <<< khronos <<<"
+
+// hookShells are the interpreters a post-commit hook may run with for the
+// block to be added to it.
+var hookShells = map[string]bool{"sh": true, "bash": true, "dash": true, "zsh": true, "ksh": true, "ash": true}
+
+// hooksCmd represents the hooks command.
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.HOOKS_SHORT_DESCRIPTION,
+	Long:  constants.HOOKS_LONG_DESCRIPTION,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install [repository...]",
+	Short: "Add the post-commit hook to the repositories, default is the current one",
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, dir := range hookRepos(args) {
+			installHook(dir)
+		}
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall [repository...]",
+	Short: "Remove the post-commit hook from the repositories, default is the current one",
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, dir := range hookRepos(args) {
+			uninstallHook(dir)
+		}
+	},
+}
+
+// hooksPostCommitCmd is what the hook runs after each commit.
+var hooksPostCommitCmd = &cobra.Command{
+	Use:    "post-commit",
+	Args:   cobra.ExactArgs(0),
+	Short:  "Record the commit just made in the current repository",
+	Hidden: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		runPostCommit()
+	},
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksPostCommitCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
+
+// hookRepos returns the top level directory of each repository given, or of
+// the current one.
+func hookRepos(args []string) []string {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	var repos []string
+	for _, arg := range args {
+		out, err := exec.Command("git", "-C", expandHome(arg), "rev-parse", "--show-toplevel").Output()
+		if err != nil {
+			log.Fatalf("%s: [%s] is not in a git repository.\n", color.RedString(constants.FATAL_NORMAL_CASE), arg)
+			os.Exit(1)
+		}
+		repos = append(repos, strings.TrimSpace(string(out)))
+	}
+
+	return repos
+}
+
+// hookPath returns the repository's post-commit hook, which git looks for in
+// core.hooksPath, if set, or the hooks directory of the git directory.
+func hookPath(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		log.Fatalf("%s: Unable to find the hooks directory of repository[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), dir, err.Error())
+		os.Exit(1)
+	}
+
+	var hooks string = strings.TrimSpace(string(out))
+	if !filepath.IsAbs(hooks) {
+		hooks = filepath.Join(dir, hooks)
+	}
+
+	return filepath.Join(hooks, "post-commit")
+}
+
+// hookCommand returns the command the hook runs.
+func hookCommand() string {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = "khronos"
+	}
+
+	return "'" + strings.ReplaceAll(executable, "'", `'\''`) + "' hooks post-commit"
+}
+
+// hookBlock returns the lines added to the hook.  Khronos runs in the
+// background, with its output discarded, so the commit never waits on it or
+// sees it fail.
+func hookBlock() string {
+	return hookBeginMarker + "\n" +
+		"# Records the commit in Khronos.  Remove with 'khronos hooks uninstall'.\n" +
+		hookCommand() + " >/dev/null 2>&1 </dev/null &\n" +
+		hookEndMarker + "\n"
+}
+
+// installHook adds the block to the repository's post-commit hook, creating
+// the hook if there is none.  The block goes right after the #! line of an
+// existing hook, so it runs even if the rest of the hook exits early.
+func installHook(dir string) {
+	var path string = hookPath(dir)
+	var block string = hookBlock()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, []byte("#!/bin/sh\n"+block), 0755)
+		}
+		if err != nil {
+			log.Fatalf("%s: Error writing hook[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), path, err.Error())
+			os.Exit(1)
+		}
+		log.Printf("%s\n", color.GreenString("Hook[%s] installed.", path))
+		return
+	} else if err != nil {
+		log.Fatalf("%s: Error reading hook[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), path, err.Error())
+		os.Exit(1)
+	}
+
+	var content string = string(data)
+	var installed string
+	if rest, found := removeHookBlock(content); found {
+		if strings.Contains(content, block) {
+			log.Printf("%s: Hook[%s] is already installed.\n", color.HiBlueString(constants.INFO_NORMAL_CASE), path)
+			return
+		}
+		// Khronos moved, so the block is replaced.
+		content = rest
+		installed = "updated"
+	} else {
+		installed = "added to the existing hook"
+	}
+
+	var shebang, body string = constants.EMPTY, content
+	if strings.HasPrefix(content, "#!") {
+		shebang, body, _ = strings.Cut(content, "\n")
+		shebang += "\n"
+		if !hookShells[hookInterpreter(shebang)] {
+			log.Printf("%s: Hook[%s] is not a shell script, so Khronos cannot add itself to it.  Have it run, in the background:\n    %s\n",
+				color.YellowString("Warning"), path, hookCommand())
+			return
+		}
+	}
+
+	// Make sure the hook is executable by whoever can read it, as git only
+	// runs an executable hook.
+	info, err := os.Stat(path)
+	if err == nil {
+		err = os.WriteFile(path, []byte(shebang+block+body), 0)
+	}
+	if err == nil {
+		err = os.Chmod(path, info.Mode().Perm()|(info.Mode().Perm()&0444)>>2)
+	}
+	if err != nil {
+		log.Fatalf("%s: Error writing hook[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), path, err.Error())
+		os.Exit(1)
+	}
+
+	log.Printf("%s\n", color.GreenString("Hook[%s] %s.", path, installed))
+}
+
+// uninstallHook removes the block from the repository's post-commit hook, and
+// the hook itself if nothing else is left in it.
+func uninstallHook(dir string) {
+	var path string = hookPath(dir)
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("%s: Error reading hook[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), path, err.Error())
+		os.Exit(1)
+	}
+
+	rest, found := removeHookBlock(string(data))
+	if !found {
+		log.Printf("%s: Hook[%s] is not installed.\n", color.HiBlueString(constants.INFO_NORMAL_CASE), path)
+		return
+	}
+
+	var remaining string = strings.TrimSpace(rest)
+	if remaining == constants.EMPTY || (strings.HasPrefix(remaining, "#!") && !strings.Contains(remaining, "\n")) {
+		err = os.Remove(path)
+	} else {
+		var info os.FileInfo
+		info, err = os.Stat(path)
+		if err == nil {
+			err = os.WriteFile(path, []byte(rest), info.Mode().Perm())
+		}
+	}
+	if err != nil {
+		log.Fatalf("%s: Error writing hook[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), path, err.Error())
+		os.Exit(1)
+	}
+
+	log.Printf("%s\n", color.GreenString("Hook[%s] uninstalled.", path))
+}
+
+// removeHookBlock returns the hook without the block, and whether it had
+// one.
+func removeHookBlock(content string) (string, bool) {
+	var begin int = strings.Index(content, hookBeginMarker)
+	if begin < 0 {
+		return content, false
+	}
+
+	var end int = strings.Index(content[begin:], hookEndMarker)
+	if end < 0 {
+		return content, false
+	}
+	end += begin + len(hookEndMarker)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	return content[:begin] + content[end:], true
+}
+
+// hookInterpreter returns the name of the program a #! line runs, e.g., bash
+// for both #!/bin/bash and #!/usr/bin/env bash.
+func hookInterpreter(shebang string) string {
+	var fields []string = strings.Fields(strings.TrimPrefix(shebang, "#!"))
+	if len(fields) == 0 {
+		return constants.EMPTY
+	}
+
+	var name string = filepath.Base(fields[0])
+	if name == "env" && len(fields) > 1 {
+		name = filepath.Base(fields[1])
+	}
+
+	return name
+}
+
+// runPostCommit records the commit just made.  It is always kept as pending
+// first, so it is not lost if, e.g., the database is locked, and then, with
+// commit_hook: entry, added as an entry if the day has a hello and the
+// repository's workspace has a project+task.
+func runPostCommit() {
+	dir, _ := os.Getwd()
+	top, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		log.Fatalf("%s: The current directory is not in a git repository.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+		os.Exit(1)
+	}
+	dir = strings.TrimSpace(string(top))
+
+	out, err := exec.Command("git", "-C", dir, "log", "-1", gitLogFormat, "HEAD").Output()
+	var commits []gitCommit = parseGitLog(dir, string(out))
+	if err != nil || len(commits) == 0 {
+		log.Fatalf("%s: Unable to read the commit just made.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+		os.Exit(1)
+	}
+
+	var c gitCommit = commits[0]
+	if gitDir, found := findGitDir(dir); found {
+		c.branch, _ = gitBranch(gitDir)
+	}
+
+	err = appendPendingCommit(c)
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	if !strings.EqualFold(viper.GetString(constants.COMMIT_HOOK), constants.COMMIT_HOOK_ENTRY) {
+		log.Printf("%s: Commit[%s] recorded for the suggest command.\n", color.HiBlueString(constants.INFO_NORMAL_CASE), c.subject)
+		return
+	}
+
+	var s suggestion = buildSuggestions([]gitCommit{c}, loadWorkspaces())[0]
+	if !s.complete() {
+		log.Printf("%s: No workspace gives repository[%s] a project+task, so commit[%s] is left for the suggest command.\n",
+			color.HiBlueString(constants.INFO_NORMAL_CASE), dir, c.subject)
+		return
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	defer db.Close()
+
+	var at carbon.Carbon = *carbon.CreateFromStdTime(c.at)
+	var entries []models.Entry = db.GetEntriesForToday(*at.Copy().StartOfDay(), *at.Copy().EndOfDay())
+	if !hasHelloBefore(db, at) {
+		log.Printf("%s: There is no %s before the commit, so commit[%s] is left for the suggest command.\n",
+			color.HiBlueString(constants.INFO_NORMAL_CASE), constants.HELLO, c.subject)
+		return
+	}
+	if last := entries[len(entries)-1]; !carbon.Parse(last.EntryDatetime).Lt(&at) {
+		log.Printf("%s: The commit is not after the last entry, so commit[%s] is left for the suggest command.\n",
+			color.HiBlueString(constants.INFO_NORMAL_CASE), c.subject)
+		return
+	}
+
+	// Classify the entry with the rules in the configuration.
+	entry, _ := applyRules(loadRules(), s.entry())
+	db.InsertNewEntry(entry)
+
+	err = removePendingCommits(func(p gitCommit) bool { return p.hash == c.hash })
+	if err != nil {
+		log.Printf("%s: %s\n", color.YellowString("Warning"), err.Error())
+	}
+
+	log.Printf("%s\n", color.GreenString("Entry added for commit[%s].", c.subject))
+}
@@ -0,0 +1,248 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"khronos/constants"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// mergeCmd represents the merge command.
+var mergeCmd = &cobra.Command{
+	Use:   "merge",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.MERGE_SHORT_DESCRIPTION,
+	Long:  constants.MERGE_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runMerge(cmd, args)
+	},
+}
+
+func init() {
+	mergeCmd.Flags().StringP(constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "Merge the entries for the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	mergeCmd.Flags().BoolP(constants.FLAG_BY_PROJECT, constants.EMPTY, false, "Merge consecutive entries for the same project, even if their tasks differ.")
+	rootCmd.AddCommand(mergeCmd)
+}
+
+func runMerge(cmd *cobra.Command, _ []string) {
+	givenDate, _ := cmd.Flags().GetString(constants.FLAG_DATE)
+	byProject, _ := cmd.Flags().GetBool(constants.FLAG_BY_PROJECT)
+
+	var day carbon.Carbon = *carbon.Now()
+	if !stringUtils.IsEmpty(givenDate) {
+		day = *carbon.Parse(givenDate)
+		if day.Error != nil {
+			log.Fatalf("%s: Invalid date[%s].  Please use %s format.\n", color.RedString(constants.FATAL_NORMAL_CASE), givenDate, constants.DATE_FORMAT_YYYY_MM_DD)
+			os.Exit(1)
+		}
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	var entries []models.Entry = db.GetEntriesForToday(*day.Copy().StartOfDay(), *day.Copy().EndOfDay())
+
+	var groups [][]models.Entry = consecutiveGroups(entries, byProject)
+	if len(groups) == 0 {
+		log.Printf("%s\n", color.YellowString("Nothing to merge."))
+		return
+	}
+
+	var uids []int64
+	var mergedEntries []models.Entry
+
+	// Create a table to show the old verses new values.
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{"", constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, constants.NOTE_NORMAL_CASE,
+		constants.TICKET_NORMAL_CASE, constants.DATE_TIME_NORMAL_CASE})
+
+	for _, group := range groups {
+		var merged models.Entry = mergeEntries(group)
+
+		for _, e := range group {
+			uids = append(uids, e.Uid)
+			t.AppendRow(table.Row{"Old", e.Project, e.GetTasksAsString(), e.Note, e.GetTicketAsString(),
+				carbon.Parse(e.EntryDatetime).ToIso8601String(carbon.Local)})
+		}
+
+		t.AppendRow(table.Row{"New", merged.Project, merged.GetTasksAsString(), merged.Note, merged.GetTicketAsString(),
+			carbon.Parse(merged.EntryDatetime).ToIso8601String(carbon.Local)})
+		t.AppendSeparator()
+
+		mergedEntries = append(mergedEntries, merged)
+	}
+
+	// Render the table.
+	log.Println(t.Render())
+
+	// Ask the user if they want to commit these changes or not.
+	yesNo := yesNoPrompt("\nMerge %d entries into %d?", len(uids), len(mergedEntries))
+	if yesNo {
+		db.ReplaceEntries(uids, mergedEntries)
+		log.Printf("%s\n", color.GreenString("Entries merged."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Entries NOT merged."))
+	}
+}
+
+// consecutiveGroups returns each run of two or more consecutive entries for the
+// same project+task, or just the same project if byProject is set.  HELLOs are
+// never merged, and runs that contain pushed entries or more than one ticket
+// are skipped with a warning.
+func consecutiveGroups(entries []models.Entry, byProject bool) [][]models.Entry {
+	var groups [][]models.Entry
+	var run []models.Entry
+
+	flush := func() {
+		if len(run) >= 2 && mergeable(run) {
+			groups = append(groups, run)
+		}
+		run = nil
+	}
+
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Project, constants.HELLO) {
+			flush()
+			continue
+		}
+
+		if len(run) > 0 && !strings.EqualFold(mergeKey(run[0], byProject), mergeKey(entry, byProject)) {
+			flush()
+		}
+
+		run = append(run, entry)
+	}
+	flush()
+
+	return groups
+}
+
+func mergeKey(entry models.Entry, byProject bool) string {
+	if byProject {
+		return entry.Project
+	}
+
+	return entryProjectTask(entry)
+}
+
+func mergeable(run []models.Entry) bool {
+	var ticket string = constants.EMPTY
+
+	for _, e := range run {
+		if e.IsPushed() {
+			log.Printf("%s: Skipping %d %s entries since at least one was already pushed.\n",
+				color.HiBlueString(constants.INFO_NORMAL_CASE), len(run), entryProjectTask(run[0]))
+			return false
+		}
+
+		var t string = e.GetTicketAsString()
+		if !stringUtils.IsBlank(t) {
+			if !stringUtils.IsBlank(ticket) && !strings.EqualFold(ticket, t) {
+				log.Printf("%s: Skipping %d %s entries since they have different tickets.\n",
+					color.HiBlueString(constants.INFO_NORMAL_CASE), len(run), entryProjectTask(run[0]))
+				return false
+			}
+			ticket = t
+		}
+	}
+
+	return true
+}
+
+// mergeEntries combines a run of entries into one entry that ends where the
+// last entry of the run ended.  The tasks and notes of the run are combined,
+// dropping duplicates, as are their other properties, e.g., tags.  When the
+// entries give a property different values, the last one wins, with a warning.
+func mergeEntries(run []models.Entry) models.Entry {
+	var notes []string
+	for _, e := range run {
+		var found bool = false
+		for _, n := range notes {
+			if strings.EqualFold(n, e.Note) {
+				found = true
+				break
+			}
+		}
+
+		if !found && !stringUtils.IsBlank(e.Note) {
+			notes = append(notes, e.Note)
+		}
+	}
+
+	var last models.Entry = run[len(run)-1]
+	var merged models.Entry = models.NewEntry(constants.UNKNOWN_UID, run[0].Project, strings.Join(notes, "; "), last.EntryDatetime)
+
+	// Tasks and tags can have several values; any other property has one.  The
+	// merged entry is a new worklog, so the push state is not kept.
+	var names []string
+	var values map[string]string = make(map[string]string)
+	for _, e := range run {
+		for _, p := range e.Properties {
+			if strings.EqualFold(p.Name, constants.TASK) || strings.EqualFold(p.Name, constants.TAG) {
+				merged.AddEntryProperty(p.Name, p.Value)
+				continue
+			}
+			if models.IsPushProperty(p.Name) {
+				continue
+			}
+
+			value, found := values[p.Name]
+			if !found {
+				names = append(names, p.Name)
+			} else if !strings.EqualFold(value, p.Value) {
+				log.Printf("%s: The %s entries have different values for %s[%s, %s]; keeping %s.\n", color.YellowString("Warning"),
+					entryProjectTask(run[0]), p.Name, value, p.Value, p.Value)
+			}
+			values[p.Name] = p.Value
+		}
+	}
+
+	for _, name := range names {
+		merged.AddEntryProperty(name, values[name])
+	}
+
+	if !stringUtils.IsBlank(merged.GetTicketAsString()) {
+		merged.AddEntryProperty(constants.PUSHED, constants.EMPTY)
+	}
+
+	return merged
+}
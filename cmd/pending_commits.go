@@ -0,0 +1,219 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"khronos/constants"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/spf13/viper"
+)
+
+// pendingCommitDays is how long a pending commit is kept before it is dropped
+// without having been suggested.
+const pendingCommitDays = 30
+
+// pendingCommitsLockWait is how long to wait for the lock on the pending
+// commits file, and pendingCommitsLockStale how old a lock must be to be taken
+// over, as left behind by a process that died holding it.
+const pendingCommitsLockWait = 5 * time.Second
+const pendingCommitsLockStale = 30 * time.Second
+
+// pendingCommit is a commit recorded by the post-commit hook, as written to
+// the pending commits file, one JSON object per line.
+type pendingCommit struct {
+	Hash       string    `json:"hash"`
+	Repository string    `json:"repository"`
+	At         time.Time `json:"at"`
+	Branch     string    `json:"branch"`
+	Subject    string    `json:"subject"`
+}
+
+// pendingCommitsFile returns the file the pending commits are kept in, next
+// to the database.  It is a plain file, rather than a table, so the hook can
+// record a commit even when the database is locked.
+func pendingCommitsFile() string {
+	return viper.GetString(constants.DATABASE_FILE) + ".pending"
+}
+
+// readPendingCommits returns the pending commits, in the order they were
+// recorded.  Lines that cannot be read are skipped.
+func readPendingCommits() []gitCommit {
+	file, err := os.Open(pendingCommitsFile())
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var commits []gitCommit
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var p pendingCommit
+		if json.Unmarshal(scanner.Bytes(), &p) != nil || p.Hash == constants.EMPTY {
+			continue
+		}
+		commits = append(commits, gitCommit{hash: p.Hash, dir: p.Repository, at: p.At, branch: p.Branch, subject: p.Subject})
+	}
+
+	return commits
+}
+
+// lockPendingCommits takes the lock on the pending commits file, a lock file
+// next to it only one process can create, returning the function releasing
+// it.  It is held while the file is appended to or rewritten, so a commit
+// recorded by a hook running in the background is never lost.
+func lockPendingCommits() (func(), error) {
+	var path string = pendingCommitsFile() + ".lock"
+	var deadline time.Time = time.Now().Add(pendingCommitsLockWait)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("error locking pending commits file[%s]. %s", pendingCommitsFile(), err.Error())
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > pendingCommitsLockStale {
+			os.Remove(path)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("error locking pending commits file[%s]. The lock[%s] is held by another process", pendingCommitsFile(), path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// appendPendingCommit records the commit as pending, unless it already is.
+func appendPendingCommit(c gitCommit) error {
+	unlock, err := lockPendingCommits()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, p := range readPendingCommits() {
+		if p.hash == c.hash {
+			return nil
+		}
+	}
+
+	data, err := json.Marshal(pendingCommit{Hash: c.hash, Repository: c.dir, At: c.at, Branch: c.branch, Subject: c.subject})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(pendingCommitsFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing pending commits file[%s]. %s", pendingCommitsFile(), err.Error())
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing pending commits file[%s]. %s", pendingCommitsFile(), err.Error())
+	}
+
+	return nil
+}
+
+// removePendingCommits drops the pending commits for which drop returns true,
+// along with those older than pendingCommitDays.  The file is removed once no
+// commits are pending.
+func removePendingCommits(drop func(c gitCommit) bool) error {
+	unlock, err := lockPendingCommits()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var path string = pendingCommitsFile()
+	var oldest time.Time = carbon.Now().SubDays(pendingCommitDays).StdTime()
+
+	var data []byte
+	var kept int
+	for _, c := range readPendingCommits() {
+		if drop(c) || c.at.Before(oldest) {
+			continue
+		}
+
+		line, err := json.Marshal(pendingCommit{Hash: c.hash, Repository: c.dir, At: c.at, Branch: c.branch, Subject: c.subject})
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+		kept++
+	}
+
+	if kept == 0 {
+		err := os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing pending commits file[%s]. %s", path, err.Error())
+		}
+		return nil
+	}
+
+	// Write a temporary file and rename it into place, so a failure never
+	// loses the pending commits.
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error writing pending commits file[%s]. %s", path, err.Error())
+	}
+	defer os.Remove(temp.Name())
+
+	_, err = temp.Write(data)
+	if err == nil {
+		err = temp.Close()
+	} else {
+		temp.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(temp.Name(), path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error writing pending commits file[%s]. %s", path, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,319 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+	"khronos/internal/util"
+)
+
+// pushCmd represents the push command.
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.PUSH_SHORT_DESCRIPTION,
+	Long:  constants.PUSH_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runPush(cmd)
+	},
+}
+
+// pushOutcome is what became of an entry that was, or was not, pushed to a
+// target.
+type pushOutcome struct {
+	entry  models.Entry
+	target string
+	result string
+	reason string
+}
+
+const (
+	pushResultPushed  = "Pushed"
+	pushResultSkipped = "Skipped"
+	pushResultFailed  = "Failed"
+)
+
+func init() {
+	pushCmd.Flags().StringP(constants.FLAG_FROM, constants.EMPTY, constants.EMPTY, "Push the entries from the given day, in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	pushCmd.Flags().StringP(constants.FLAG_TO, constants.EMPTY, constants.EMPTY, "Push the entries up to the given day, in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	pushCmd.Flags().StringP(constants.FLAG_TICKET, constants.EMPTY, constants.EMPTY, "Push the entries whose ticket matches, with * and ? wildcards, e.g., ABC-*.")
+	pushCmd.Flags().BoolP(constants.FLAG_ALL, constants.EMPTY, false, "Push all the unpushed entries instead of choosing them from a checklist.")
+	pushCmd.Flags().BoolP(constants.DRY_RUN, constants.EMPTY, false, "Show the JSON payloads and URLs that would be pushed without pushing them.")
+
+	rootCmd.AddCommand(pushCmd)
+}
+
+func runPush(cmd *cobra.Command) {
+	from, _ := cmd.Flags().GetString(constants.FLAG_FROM)
+	to, _ := cmd.Flags().GetString(constants.FLAG_TO)
+	ticketPattern, _ := cmd.Flags().GetString(constants.FLAG_TICKET)
+	all, _ := cmd.Flags().GetBool(constants.FLAG_ALL)
+	dryRun, _ := cmd.Flags().GetBool(constants.DRY_RUN)
+
+	var roundTo int64 = viper.GetInt64(constants.ROUND_TO_MINUTES)
+	var targets []pushTarget = loadPushTargets()
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	defer db.Close()
+
+	var start, end carbon.Carbon
+	if !stringUtils.IsEmpty(from) {
+		start = parseBulkDate(from)
+		start = *start.StartOfDay()
+	}
+	if !stringUtils.IsEmpty(to) {
+		end = parseBulkDate(to)
+		end = *end.EndOfDay()
+		if !stringUtils.IsEmpty(from) && end.Lt(&start) {
+			log.Fatalf("%s: --%s[%s] must not be before --%s[%s].\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.FLAG_TO, to, constants.FLAG_FROM, from)
+			os.Exit(1)
+		}
+	}
+
+	// Besides the entries not yet pushed, those pushed to the push targets may
+	// still need pushing to a target added since.
+	var unpushed []models.Entry = append(db.GetUnpushedEntries(), db.GetEntriesPushedToTargets()...)
+	sort.SliceStable(unpushed, func(i, j int) bool {
+		return carbon.Parse(unpushed[i].EntryDatetime).Lt(carbon.Parse(unpushed[j].EntryDatetime))
+	})
+
+	// Only the entries within the dates and with a matching ticket are looked
+	// at.  Of those, the ones that cannot be pushed are skipped.
+	var candidates []models.Entry
+	var outcomes []pushOutcome
+	for _, entry := range unpushed {
+		var at carbon.Carbon = *carbon.Parse(entry.EntryDatetime)
+		if (!stringUtils.IsEmpty(from) && at.Lt(&start)) || (!stringUtils.IsEmpty(to) && at.Gt(&end)) {
+			continue
+		}
+
+		var ticket string = entry.GetTicketAsString()
+		if !stringUtils.IsEmpty(ticketPattern) && !globMatch(ticketPattern, ticket) {
+			continue
+		}
+
+		if !stringUtils.IsBlank(entry.GetPushedAsString()) {
+			if pushedToAny(targets, entry) {
+				candidates = append(candidates, entry)
+			}
+		} else if stringUtils.IsBlank(ticket) {
+			outcomes = append(outcomes, pushOutcome{entry, constants.EMPTY, pushResultSkipped, "It has no ticket"})
+		} else if !pushedToAny(targets, entry) {
+			outcomes = append(outcomes, pushOutcome{entry, constants.EMPTY, pushResultSkipped, "No push target's ticket_pattern matches its ticket"})
+		} else {
+			candidates = append(candidates, entry)
+		}
+	}
+
+	if len(candidates) == 0 && len(outcomes) == 0 {
+		log.Printf("%s\n", color.YellowString("No unpushed entries found."))
+		return
+	}
+
+	// Choose the entries to push, all of them to start with.
+	var chosen []models.Entry = candidates
+	if !all && len(candidates) > 0 {
+		indexes, ok, err := selectEntries("Select the entries to push", candidates, true)
+		if err != nil {
+			log.Fatalf("%s: Error running entry selector. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		if !ok {
+			log.Printf("%s\n", color.YellowString("Nothing pushed."))
+			return
+		}
+
+		var selected map[int]bool = make(map[int]bool)
+		for _, i := range indexes {
+			selected[i] = true
+		}
+
+		chosen = nil
+		for i, entry := range candidates {
+			if selected[i] {
+				chosen = append(chosen, entry)
+			} else {
+				outcomes = append(outcomes, pushOutcome{entry, constants.EMPTY, pushResultSkipped, "Not selected"})
+			}
+		}
+	}
+
+	jobs, err := newPushJobs(targets, pushEntryPieces(db, chosen), roundTo)
+	if err != nil {
+		log.Fatalf("%s: %v\n", color.RedString(constants.FATAL_NORMAL_CASE), err)
+		os.Exit(1)
+	}
+
+	if dryRun {
+		for _, job := range jobs {
+			log.Printf("%s[%s] %s %s %s\n%s %s\n%s\n\n", color.YellowString("Push target"), job.target.Name,
+				carbon.Parse(job.entry.EntryDatetime).ToIso8601String(carbon.Local), entryProjectTask(job.entry), job.request.Ticket,
+				job.request.Method, job.request.URL, job.request.Payload)
+		}
+		renderPushOutcomes(outcomes)
+		log.Printf("%s\n", color.YellowString("Dry run, %d entries NOT pushed.", len(chosen)))
+		return
+	}
+
+	if len(jobs) == 0 {
+		renderPushOutcomes(outcomes)
+		log.Printf("%s\n", color.YellowString("Nothing pushed."))
+		return
+	}
+
+	yesNo := yesNoPrompt("\nPush these %d entries?", len(chosen))
+	if !yesNo {
+		log.Printf("%s\n", color.YellowString("Entries NOT pushed."))
+		return
+	}
+
+	var remaining map[int64]int = make(map[int64]int)
+	for _, job := range jobs {
+		remaining[job.entry.Uid]++
+	}
+
+	// Push every entry to every target, even if some of them fail.
+	var failed int
+	util.RunWithSpinner("Pushing entries", func() error {
+		for _, job := range jobs {
+			remoteID, err := runPushJob(db, job, remaining)
+			if err != nil {
+				failed++
+				outcomes = append(outcomes, pushOutcome{job.entry, job.target.Name, pushResultFailed, err.Error()})
+			} else {
+				outcomes = append(outcomes, pushOutcome{job.entry, job.target.Name, pushResultPushed, "Remote ID[" + remoteID + "]"})
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d failed", failed)
+		}
+		return nil
+	})
+
+	renderPushOutcomes(outcomes)
+
+	if failed > 0 {
+		log.Printf("%s: %d of the pushes failed; push again to retry them.\n", color.RedString(constants.FATAL_NORMAL_CASE), failed)
+		os.Exit(1)
+	}
+
+	log.Printf("%s\n", color.GreenString("Entries pushed."))
+}
+
+// pushedToAny reports whether any of the targets pushes the entry.
+func pushedToAny(targets []pushTarget, entry models.Entry) bool {
+	for _, target := range targets {
+		if target.pushes(entry) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pushEntryPieces returns the entries with their durations, worked out just
+// as the report does: an entry running over midnight is two, one for each
+// day.
+func pushEntryPieces(db *database.Database, entries []models.Entry) []models.Entry {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var uids map[int64]bool = make(map[int64]bool)
+	var start carbon.Carbon = *carbon.Parse(entries[0].EntryDatetime)
+	var end carbon.Carbon = start
+	for _, entry := range entries {
+		uids[entry.Uid] = true
+
+		var at carbon.Carbon = *carbon.Parse(entry.EntryDatetime)
+		if at.Lt(&start) {
+			start = at
+		}
+		if at.Gt(&end) {
+			end = at
+		}
+	}
+
+	// Start the day before, for an entry running over midnight.
+	var pieces []models.Entry
+	for _, piece := range reportEntries(db, *start.SubDay().StartOfDay(), *end.EndOfDay(), constants.EMPTY) {
+		if uids[piece.Uid] {
+			pieces = append(pieces, piece)
+		}
+	}
+
+	return pieces
+}
+
+// renderPushOutcomes shows the entries pushed, skipped, and failed, in order.
+func renderPushOutcomes(outcomes []pushOutcome) {
+	if len(outcomes) == 0 {
+		return
+	}
+
+	sort.SliceStable(outcomes, func(i, j int) bool {
+		return carbon.Parse(outcomes[i].entry.EntryDatetime).Lt(carbon.Parse(outcomes[j].entry.EntryDatetime))
+	})
+
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.DATE_TIME_NORMAL_CASE, "Project+Task", constants.TICKET_NORMAL_CASE, "Target", "Result", "Reason"})
+	t.SetColumnConfigs([]table.ColumnConfig{{Number: 6, WidthMax: 60}})
+
+	var counts map[string]int = make(map[string]int)
+	for _, o := range outcomes {
+		t.AppendRow(table.Row{carbon.Parse(o.entry.EntryDatetime).ToIso8601String(carbon.Local), entryProjectTask(o.entry),
+			o.entry.GetTicketAsString(), o.target, o.result, strings.TrimSpace(o.reason)})
+		counts[o.result]++
+	}
+
+	t.AppendFooter(table.Row{constants.EMPTY, constants.EMPTY, constants.EMPTY, constants.EMPTY, constants.EMPTY,
+		fmt.Sprintf("%d pushed, %d skipped, %d failed", counts[pushResultPushed], counts[pushResultSkipped], counts[pushResultFailed])})
+
+	log.Printf("\n%s\n\n", t.Render())
+}
@@ -0,0 +1,309 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+	"khronos/internal/push"
+)
+
+// pushTargetNameRegex matches a push target's name, which becomes part of the
+// names of the properties recording what was pushed to it.
+var pushTargetNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// pushTarget is a push target ready to be pushed to.  others holds the names
+// of the other push targets.
+type pushTarget struct {
+	push.Target
+	pusher  push.Pusher
+	tickets *regexp.Regexp
+	others  []string
+}
+
+// pushJob is an entry to be pushed to a target.
+type pushJob struct {
+	target  pushTarget
+	entry   models.Entry
+	request push.Request
+}
+
+// readPushTargets returns the push targets in the configuration: those in
+// push.targets or, without any, the push settings themselves as the default
+// target.  A target without a type is of the push.type.
+func readPushTargets() ([]push.Target, error) {
+	var targets []push.Target
+	err := viper.UnmarshalKey(constants.PUSH_TARGETS, &targets)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targets) == 0 {
+		return []push.Target{{
+			Name:     constants.PUSH_TARGET_DEFAULT,
+			Type:     viper.GetString(constants.PUSH_TYPE),
+			URL:      viper.GetString(constants.PUSH_URL),
+			Username: viper.GetString(constants.PUSH_USERNAME),
+			APIKey:   viper.GetString(constants.PUSH_API_KEY),
+		}}, nil
+	}
+
+	var names map[string]bool = make(map[string]bool)
+	for i := range targets {
+		if err := checkPushTargetName(targets[i].Name, i+1, names); err != nil {
+			return nil, err
+		}
+
+		if stringUtils.IsBlank(targets[i].Type) {
+			targets[i].Type = viper.GetString(constants.PUSH_TYPE)
+		}
+	}
+
+	return targets, nil
+}
+
+// checkPushTargetName checks the name of the 1-based numbered push target is
+// valid and not one of the names already seen, which it is added to.
+func checkPushTargetName(name string, number int, names map[string]bool) error {
+	if !pushTargetNameRegex.MatchString(name) {
+		return fmt.Errorf("push target[%d] has an invalid name[%s], must be letters, digits, '-', and '_'", number, name)
+	}
+
+	if names[strings.ToLower(name)] {
+		return fmt.Errorf("push target[%d] has the same name[%s] as another", number, name)
+	}
+	names[strings.ToLower(name)] = true
+
+	return nil
+}
+
+// compilePushTarget creates the Pusher of the target's type and compiles its
+// ticket pattern.
+func compilePushTarget(target push.Target) (pushTarget, error) {
+	pusher, err := push.New(target)
+	if err != nil {
+		return pushTarget{}, err
+	}
+
+	var compiled pushTarget = pushTarget{Target: target, pusher: pusher}
+	if target.TicketPattern != constants.EMPTY {
+		compiled.tickets, err = regexp.Compile("^(?:" + target.TicketPattern + ")$")
+		if err != nil {
+			return pushTarget{}, fmt.Errorf("push target[%s] has an invalid ticket_pattern[%s]. %s", target.Name, target.TicketPattern, err.Error())
+		}
+	}
+
+	return compiled, nil
+}
+
+// checkPushTargets checks the names, types, and ticket patterns of the push
+// targets, and that the push.target is one of them, as every command does when
+// it starts.  Their credentials are only needed to push, so they are left to
+// loadPushTargets.
+func checkPushTargets() error {
+	targets, err := readPushTargets()
+	if err != nil {
+		return err
+	}
+
+	if err := checkPushTargetChosen(targets); err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		if !push.IsType(target.Type) {
+			if len(targets) == 1 && target.Name == constants.PUSH_TARGET_DEFAULT {
+				return fmt.Errorf("push type[%s] is invalid, must be %s", target.Type, strings.Join(push.Types(), " or "))
+			}
+			return fmt.Errorf("push target[%s] has an unknown type[%s], must be %s", target.Name, target.Type, strings.Join(push.Types(), " or "))
+		}
+
+		if target.TicketPattern != constants.EMPTY {
+			if _, err := regexp.Compile("^(?:" + target.TicketPattern + ")$"); err != nil {
+				return fmt.Errorf("push target[%s] has an invalid ticket_pattern[%s]. %s", target.Name, target.TicketPattern, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPushTargetChosen checks the push.target, if any, names one of the push
+// targets.
+func checkPushTargetChosen(targets []push.Target) error {
+	var chosen string = viper.GetString(constants.PUSH_TARGET)
+	if stringUtils.IsBlank(chosen) {
+		return nil
+	}
+
+	for _, target := range targets {
+		if strings.EqualFold(target.Name, chosen) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s[%s] is not one of the push targets", constants.PUSH_TARGET, chosen)
+}
+
+// loadPushTargets returns the push targets, ready to be pushed to, exiting if
+// any of them is not configured correctly.  When the push.target is set, e.g.,
+// by a repository's per-directory configuration file, only that target is
+// returned.
+func loadPushTargets() []pushTarget {
+	targets, err := readPushTargets()
+	if err == nil {
+		err = checkPushTargetChosen(targets)
+	}
+	if err != nil {
+		log.Fatalf("%s: Error reading the push targets from the configuration. %s.  Please correct your configuration.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	var compiled []pushTarget
+	for _, target := range targets {
+		c, err := compilePushTarget(target)
+		if err != nil {
+			log.Fatalf("%s: The %s.  Please correct your configuration.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+		compiled = append(compiled, c)
+	}
+
+	for i := range compiled {
+		for j := range compiled {
+			if i != j {
+				compiled[i].others = append(compiled[i].others, compiled[j].Name)
+			}
+		}
+	}
+
+	var chosen string = viper.GetString(constants.PUSH_TARGET)
+	if !stringUtils.IsBlank(chosen) {
+		for _, target := range compiled {
+			if strings.EqualFold(target.Name, chosen) {
+				return []pushTarget{target}
+			}
+		}
+	}
+
+	return compiled
+}
+
+// pushes reports whether the entry is to be pushed to the target: it has a
+// ticket matching the target's ticket pattern, if any, and has not been pushed
+// to the target yet.  An entry already pushed everywhere it was pushed to is
+// pushed to a target added since only if it was pushed to one of the other
+// targets.  Otherwise, e.g., when it was pushed before there were push
+// targets, where it was pushed is unknown, so it is not pushed again.
+func (t pushTarget) pushes(entry models.Entry) bool {
+	var ticket string = entry.GetTicketAsString()
+	if stringUtils.IsBlank(ticket) {
+		return false
+	}
+
+	if t.tickets != nil && !t.tickets.MatchString(ticket) {
+		return false
+	}
+
+	if !stringUtils.IsBlank(entry.GetPushedToAsString(t.Name)) {
+		return false
+	}
+
+	if stringUtils.IsBlank(entry.GetPushedAsString()) {
+		return true
+	}
+
+	for _, name := range t.others {
+		if !stringUtils.IsBlank(entry.GetPushedToAsString(name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newPushJobs builds the requests pushing each of the entries to the targets
+// it is to be pushed to.
+func newPushJobs(targets []pushTarget, entries []models.Entry, roundToMinutes int64) ([]pushJob, error) {
+	var jobs []pushJob
+	for _, entry := range entries {
+		for _, target := range targets {
+			if !target.pushes(entry) {
+				continue
+			}
+
+			request, err := target.pusher.NewRequest(entry, roundToMinutes)
+			if err != nil {
+				return nil, fmt.Errorf("for Entry[%s] push target[%s]: %v", entry.Dump(false, 0), target.Name, err)
+			}
+
+			jobs = append(jobs, pushJob{target: target, entry: entry, request: request})
+		}
+	}
+
+	return jobs, nil
+}
+
+// runPushJob pushes the entry to the target and records it, and, once the
+// entry has been pushed to every target it is pushed to, that it was pushed.
+// remaining counts the jobs of each entry yet to be pushed.  It returns the ID
+// the target gave the entry.
+func runPushJob(db *database.Database, job pushJob, remaining map[int64]int) (string, error) {
+	response, err := job.target.pusher.Send(job.request)
+	if err != nil {
+		return constants.EMPTY, fmt.Errorf("failed to send %s: %v", job.request.URL, err)
+	}
+
+	if viper.GetBool(constants.DEBUG) {
+		log.Printf("Push target[%s] responded: %v\n{%q}\n", job.target.Name, response.Status, response.Body)
+	}
+
+	remoteID, err := job.target.pusher.RemoteID(response)
+	if err != nil {
+		return constants.EMPTY, err
+	}
+
+	remaining[job.entry.Uid]--
+	db.UpdateEntryPushedTo(job.entry.Uid, job.target.Name, remoteID, remaining[job.entry.Uid] == 0)
+
+	return remoteID, nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/ijt/go-anytime"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// ticketRegex matches a Jira style ticket key, e.g., ABC-123.
+var ticketRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[0-9]+$`)
+
+// ticketProjectRegex matches a Jira project key, e.g., ABC.
+var ticketProjectRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]+$`)
+
+// quickAddToken records how one piece of a quick add was interpreted.
+type quickAddToken struct {
+	Text  string
+	Kind  string
+	Value string
+}
+
+// quickAdd is the result of parsing a quick add string.
+type quickAdd struct {
+	ProjectTask string
+	Time        time.Time
+	HasTime     bool
+	Ticket      string
+	Tags        []string
+	Note        string
+	Tokens      []quickAddToken
+}
+
+// quickAddWords splits the arguments of a quick add into words.  An argument
+// starting with '@' is kept whole, so a quoted time, e.g., @"10 minutes ago",
+// stays one word.
+func quickAddWords(args []string) []string {
+	var words []string
+	for _, arg := range args {
+		var trimmed string = strings.TrimSpace(arg)
+		if strings.HasPrefix(trimmed, constants.AT_PREFIX) {
+			words = append(words, trimmed)
+		} else {
+			words = append(words, strings.Fields(arg)...)
+		}
+	}
+
+	return words
+}
+
+// parseQuickAdd parses the words of a one line quick add of the form
+//
+//	project+task[+task...] [@time] [TICKET-123] [#tag...] [note...]
+//
+// The project+task must come first.  The time, ticket, and tags may follow in
+// any order.  The first word that is none of those starts the note, which runs
+// to the end of the line.  The time is a single word; a time of several words
+// is quoted, e.g., @"10 minutes ago", or joined with hyphens, e.g.,
+// @10-minutes-ago.  Times are parsed relative to now.
+func parseQuickAdd(words []string, now time.Time) (quickAdd, error) {
+	var result quickAdd
+
+	if len(words) == 0 {
+		return result, fmt.Errorf("nothing to add")
+	}
+
+	if !strings.Contains(words[0], constants.TASK_DELIMITER) {
+		return result, fmt.Errorf("expected project+task first, got [%s]", words[0])
+	}
+
+	result.ProjectTask = words[0]
+	result.Tokens = append(result.Tokens, quickAddToken{words[0], "Project+Task", words[0]})
+
+	var i int = 1
+	for i < len(words) {
+		var word string = words[i]
+
+		if strings.HasPrefix(word, constants.AT_PREFIX) {
+			if result.HasTime {
+				return result, fmt.Errorf("more than one time given, [%s]", word)
+			}
+
+			parsed, err := parseQuickAddTime(word, now)
+			if err != nil {
+				return result, err
+			}
+
+			result.Time = parsed
+			result.HasTime = true
+			result.Tokens = append(result.Tokens, quickAddToken{word, "Time", carbon.CreateFromStdTime(parsed).ToIso8601String(carbon.Local)})
+			i++
+		} else if ticketRegex.MatchString(word) {
+			if len(result.Ticket) > 0 {
+				return result, fmt.Errorf("more than one ticket given, [%s] and [%s]", result.Ticket, word)
+			}
+
+			result.Ticket = word
+			result.Tokens = append(result.Tokens, quickAddToken{word, constants.TICKET_NORMAL_CASE, word})
+			i++
+		} else if strings.HasPrefix(word, constants.TAG_PREFIX) && len(word) > len(constants.TAG_PREFIX) {
+			var tag string = strings.TrimPrefix(word, constants.TAG_PREFIX)
+			result.Tags = append(result.Tags, tag)
+			result.Tokens = append(result.Tokens, quickAddToken{word, constants.TAG_NORMAL_CASE, tag})
+			i++
+		} else {
+			// Everything from here on is the note.
+			result.Note = strings.Join(words[i:], " ")
+			result.Tokens = append(result.Tokens, quickAddToken{result.Note, constants.NOTE_NORMAL_CASE, result.Note})
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// parseQuickAddTime parses an '@time' word.  If it does not parse as is, its
+// hyphens are read as spaces, e.g., '@10-minutes-ago'.
+func parseQuickAddTime(word string, now time.Time) (time.Time, error) {
+	var value string = strings.TrimPrefix(word, constants.AT_PREFIX)
+	if len(strings.TrimSpace(value)) == 0 {
+		return time.Time{}, fmt.Errorf("expected a time after [%s]", constants.AT_PREFIX)
+	}
+
+	parsed, err := anytime.Parse(value, now)
+	if err == nil {
+		return parsed, nil
+	}
+
+	parsed, err = anytime.Parse(strings.ReplaceAll(value, "-", " "), now)
+	if err == nil {
+		return parsed, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse time [%s].  For natural date examples see https://github.com/ijt/go-anytime", word)
+}
+
+// renderQuickAdd returns a table showing how each piece of a quick add was
+// interpreted.
+func renderQuickAdd(qa quickAdd) string {
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{"Token", "Interpreted As", "Value"})
+	for _, token := range qa.Tokens {
+		t.AppendRow(table.Row{token.Text, token.Kind, token.Value})
+	}
+
+	return t.Render()
+}
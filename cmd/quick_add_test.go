@@ -0,0 +1,148 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	var now time.Time = time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		words       []string
+		projectTask string
+		time        time.Time
+		ticket      string
+		tags        []string
+		note        string
+	}{
+		{name: "project+task", words: []string{"acme+review"}, projectTask: "acme+review"},
+		{name: "several tasks", words: []string{"acme+review+docs"}, projectTask: "acme+review+docs"},
+		{name: "time", words: []string{"acme+review", "@10:45"}, projectTask: "acme+review",
+			time: time.Date(2026, 10, 16, 10, 45, 0, 0, time.UTC)},
+		{name: "quoted time", words: []string{"acme+review", "@10 minutes ago"}, projectTask: "acme+review",
+			time: time.Date(2026, 10, 16, 8, 50, 0, 0, time.UTC)},
+		{name: "hyphen joined time", words: []string{"acme+review", "@10-minutes-ago"}, projectTask: "acme+review",
+			time: time.Date(2026, 10, 16, 8, 50, 0, 0, time.UTC)},
+		{name: "time does not take note words", words: []string{"acme+review", "@10:45", "monday", "standup"},
+			projectTask: "acme+review", time: time.Date(2026, 10, 16, 10, 45, 0, 0, time.UTC), note: "monday standup"},
+		{name: "ticket", words: []string{"acme+review", "ABC-123"}, projectTask: "acme+review", ticket: "ABC-123"},
+		{name: "tags", words: []string{"acme+review", "#meeting", "#auth"}, projectTask: "acme+review",
+			tags: []string{"meeting", "auth"}},
+		{name: "note", words: []string{"acme+review", "reviewed", "the", "auth", "PR"}, projectTask: "acme+review",
+			note: "reviewed the auth PR"},
+		{name: "any order", words: []string{"acme+review", "#meeting", "ABC-123", "@10:45", "reviewed", "the", "PR"},
+			projectTask: "acme+review", time: time.Date(2026, 10, 16, 10, 45, 0, 0, time.UTC), ticket: "ABC-123",
+			tags: []string{"meeting"}, note: "reviewed the PR"},
+		{name: "note keeps later tickets and tags", words: []string{"acme+review", "fixed", "ABC-9", "#today"},
+			projectTask: "acme+review", note: "fixed ABC-9 #today"},
+		{name: "lone hash starts the note", words: []string{"acme+review", "#", "done"}, projectTask: "acme+review",
+			note: "# done"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qa, err := parseQuickAdd(tt.words, now)
+			if err != nil {
+				t.Fatalf("parseQuickAdd(%q) returned error: %v", tt.words, err)
+			}
+
+			if qa.ProjectTask != tt.projectTask {
+				t.Errorf("ProjectTask = %q, want %q", qa.ProjectTask, tt.projectTask)
+			}
+			if qa.HasTime != !tt.time.IsZero() || !qa.Time.Equal(tt.time) {
+				t.Errorf("Time = %v (HasTime %v), want %v", qa.Time, qa.HasTime, tt.time)
+			}
+			if qa.Ticket != tt.ticket {
+				t.Errorf("Ticket = %q, want %q", qa.Ticket, tt.ticket)
+			}
+			if !reflect.DeepEqual(qa.Tags, tt.tags) {
+				t.Errorf("Tags = %q, want %q", qa.Tags, tt.tags)
+			}
+			if qa.Note != tt.note {
+				t.Errorf("Note = %q, want %q", qa.Note, tt.note)
+			}
+		})
+	}
+}
+
+func TestParseQuickAddErrors(t *testing.T) {
+	var now time.Time = time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		words []string
+		err   string
+	}{
+		{name: "nothing", words: nil, err: "nothing to add"},
+		{name: "no project+task", words: []string{"acme", "review"}, err: "expected project+task first"},
+		{name: "two times", words: []string{"acme+review", "@10:45", "@11:00"}, err: "more than one time"},
+		{name: "two tickets", words: []string{"acme+review", "ABC-1", "ABC-2"}, err: "more than one ticket"},
+		{name: "empty time", words: []string{"acme+review", "@"}, err: "expected a time"},
+		{name: "bad time", words: []string{"acme+review", "@whenever"}, err: "unable to parse time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseQuickAdd(tt.words, now)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parseQuickAdd(%q) error = %v, want %q", tt.words, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestQuickAddWords(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "one argument", args: []string{"acme+review @10:45 done"}, want: []string{"acme+review", "@10:45", "done"}},
+		{name: "quoted time stays whole", args: []string{"acme+review", "@10 minutes ago", "done"},
+			want: []string{"acme+review", "@10 minutes ago", "done"}},
+		{name: "quoted note is split", args: []string{"acme+review", "fixed the build"},
+			want: []string{"acme+review", "fixed", "the", "build"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quickAddWords(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("quickAddWords(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
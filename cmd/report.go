@@ -31,18 +31,14 @@ POSSIBILITY OF SUCH DAMAGE.
 package cmd
 
 import (
-	"bytes"
 	"fmt"
-	"io"
 	"khronos/constants"
 	"khronos/internal/database"
 	"khronos/internal/jira"
 	"khronos/internal/models"
-	"khronos/internal/rest"
 	"khronos/internal/util"
 	"log"
 	"math"
-	"net/http"
 	"os"
 	"sort"
 	"strconv"
@@ -66,12 +62,10 @@ var to string
 var givenDate string
 var project string
 var daysOfWeek = map[string]time.Weekday{}
-var roundToMinutes int64
 var exportFilename string = constants.EMPTY
-var _cmd *cobra.Command
 var exportType = models.ExportTypeCSV
 var startEndTimeFormat string = constants.CARBON_START_END_TIME_FORMAT
-var pushCredentials models.Credentials
+var pushTargets []pushTarget
 var SUB int = 40
 
 // reportCmd represents the report command.
@@ -111,10 +105,10 @@ func dateRange(start *carbon.Carbon, end *carbon.Carbon) {
 	*end = *end.AddDays(6).EndOfDay()
 }
 
-func export(title string, t table.Writer) {
-	exporting, _ := _cmd.Flags().GetBool(constants.EXPORT)
+func export(cmd *cobra.Command, title string, t table.Writer) {
+	exporting, _ := cmd.Flags().GetBool(constants.EXPORT)
 	if exporting {
-		typeStr, _ := _cmd.Flags().GetString(constants.EXPORT_TYPE)
+		typeStr, _ := cmd.Flags().GetString(constants.EXPORT_TYPE)
 		if len(strings.TrimSpace(exportFilename)) == 0 {
 			// Create our new export file.
 			exportFilename = constants.APPLICATION_NAME_LOWERCASE + "_report_" + carbon.Now(carbon.Local).ToShortDateTimeString()
@@ -176,6 +170,10 @@ func init() {
 	reportCmd.Flags().BoolP(constants.EXPORT, constants.EMPTY, false, "Export to file.")
 	reportCmd.Flags().Var(&exportType, constants.EXPORT_TYPE, `Type of export file.  Allowed values: "csv", "html" or "md"`)
 	reportCmd.MarkFlagsRequiredTogether(constants.EXPORT, constants.EXPORT_TYPE)
+	reportCmd.Flags().BoolP(constants.FLAG_INTERACTIVE, constants.EMPTY, false, "Browse the report interactively.")
+	reportCmd.MarkFlagsMutuallyExclusive(constants.FLAG_INTERACTIVE, constants.EXPORT)
+	reportCmd.MarkFlagsMutuallyExclusive(constants.FLAG_INTERACTIVE, constants.FLAG_PUSH)
+	reportCmd.MarkFlagsMutuallyExclusive(constants.FLAG_INTERACTIVE, constants.FLAG_LAST_ENTRY)
 	rootCmd.AddCommand(reportCmd)
 
 	// Here you will define your flags and configuration settings.
@@ -215,7 +213,7 @@ func plural(count int, singular string) (result string) {
 	return
 }
 
-func reportByDay(entries []models.Entry) {
+func reportByDay(cmd *cobra.Command, entries []models.Entry, roundTo int64) {
 	var display_by_day_totals bool = viper.GetBool(constants.DISPLAY_BY_DAY_TOTALS)
 	log.Printf("\n")
 	log.Printf("%s\n", separator(" By Day "))
@@ -234,13 +232,13 @@ func reportByDay(entries []models.Entry) {
 				}
 
 				// Add the rounded durations together.
-				consolidatedProject.Duration += util.Round(roundToMinutes, entry.Duration)
+				consolidatedProject.Duration += util.Round(roundTo, entry.Duration)
 
 				// Replace the consolidated entry.
 				consolidatedByDay[carbon.Parse(entry.EntryDatetime).Format(constants.CARBON_DATE_FORMAT)][entry.Project] = consolidatedProject
 			} else {
 				var newEntry models.Entry = models.NewEntry(entry.Uid, entry.Project, entry.Note, entry.EntryDatetime)
-				newEntry.Duration = util.Round(roundToMinutes, entry.Duration)
+				newEntry.Duration = util.Round(roundTo, entry.Duration)
 				newEntry.Properties = entry.Properties
 
 				// Add the new entry.
@@ -249,7 +247,7 @@ func reportByDay(entries []models.Entry) {
 		} else {
 			// Since the EntryDatetime was not found, add it.
 			var newEntry models.Entry = models.NewEntry(entry.Uid, entry.Project, entry.Note, entry.EntryDatetime)
-			newEntry.Duration = util.Round(roundToMinutes, entry.Duration)
+			newEntry.Duration = util.Round(roundTo, entry.Duration)
 			newEntry.Properties = entry.Properties
 
 			// Add the new entry.
@@ -295,13 +293,13 @@ func reportByDay(entries []models.Entry) {
 		var totalPerDay int64 = 0
 
 		for p, v := range day {
-			t.AppendRow(table.Row{i, p, v.GetTasksAsString(), constants.EMPTY, secondsToHuman(v.Duration, true)})
-			totalPerDay += util.Round(roundToMinutes, v.Duration)
+			t.AppendRow(table.Row{i, p, v.GetTasksAsString(), constants.EMPTY, secondsToHuman(v.Duration, true, roundTo)})
+			totalPerDay += util.Round(roundTo, v.Duration)
 		}
 
 		if display_by_day_totals {
 			t.AppendSeparator()
-			t.AppendRow(table.Row{constants.EMPTY, constants.EMPTY, constants.EMPTY, constants.TOTAL, stringUtils.UpperCase(secondsToHuman(totalPerDay, true))})
+			t.AppendRow(table.Row{constants.EMPTY, constants.EMPTY, constants.EMPTY, constants.TOTAL, stringUtils.UpperCase(secondsToHuman(totalPerDay, true, roundTo))})
 			t.AppendSeparator()
 		}
 	}
@@ -310,10 +308,10 @@ func reportByDay(entries []models.Entry) {
 	log.Println(t.Render())
 
 	// Export table if needed.
-	export("report by day", t)
+	export(cmd, "report by day", t)
 }
 
-func reportByEntry(entries []models.Entry) {
+func reportByEntry(cmd *cobra.Command, entries []models.Entry, roundTo int64) {
 	log.Printf("\n")
 	log.Printf("%s\n", separator(" By Entry "))
 	log.Printf("\n")
@@ -421,7 +419,7 @@ func reportByEntry(entries []models.Entry) {
 
 		var endString string = end.Format(constants.CARBON_DATE_FORMAT)
 		var startString string = start.Format(startEndTimeFormat) + " to " + end.Format(startEndTimeFormat)
-		var durationString string = secondsToHuman(util.Round(roundToMinutes, entry.Duration), true)
+		var durationString string = secondsToHuman(util.Round(roundTo, entry.Duration), true, roundTo)
 		var projectString string = entry.Project
 		var taskString string = entry.GetTasksAsString()
 		var noteString string = entry.Note
@@ -450,7 +448,7 @@ func reportByEntry(entries []models.Entry) {
 	log.Println(t.Render())
 
 	// Export table if needed.
-	export("report by entry", t)
+	export(cmd, "report by entry", t)
 }
 
 func reportByLastEntry() {
@@ -467,7 +465,7 @@ func reportByLastEntry() {
 	}
 }
 
-func reportByProject(entries []models.Entry) {
+func reportByProject(cmd *cobra.Command, entries []models.Entry, roundTo int64) {
 	log.Printf("\n")
 	log.Printf("%s\n", separator(" By Project "))
 	log.Printf("\n")
@@ -482,11 +480,11 @@ func reportByProject(entries []models.Entry) {
 			if len(entry.GetTasksAsString()) > 0 {
 				consolidated.AddEntryProperty(constants.TASK, entry.GetTasksAsString())
 			}
-			consolidated.Duration += util.Round(roundToMinutes, entry.Duration)
+			consolidated.Duration += util.Round(roundTo, entry.Duration)
 			consolidatedByProject[entry.Project] = consolidated
 		} else {
 			var newEntry models.Entry = models.NewEntry(entry.Uid, entry.Project, entry.Note, entry.EntryDatetime)
-			newEntry.Duration = util.Round(roundToMinutes, entry.Duration)
+			newEntry.Duration = util.Round(roundTo, entry.Duration)
 			if len(entry.GetTasksAsString()) > 0 {
 				newEntry.AddEntryProperty(constants.TASK, entry.GetTasksAsString())
 			}
@@ -514,7 +512,7 @@ func reportByProject(entries []models.Entry) {
 
 		// Skip entries that match constants.HELLO.
 		if !strings.EqualFold(entry.Project, constants.HELLO) {
-			t.AppendRow(table.Row{entry.Project, entry.GetTasksAsString(), secondsToHuman(entry.Duration, true)})
+			t.AppendRow(table.Row{entry.Project, entry.GetTasksAsString(), secondsToHuman(entry.Duration, true, roundTo)})
 		}
 	}
 
@@ -522,10 +520,10 @@ func reportByProject(entries []models.Entry) {
 	log.Println(t.Render())
 
 	// Export table if needed.
-	export("report by project", t)
+	export(cmd, "report by project", t)
 }
 
-func reportByTask(entries []models.Entry) {
+func reportByTask(cmd *cobra.Command, entries []models.Entry, roundTo int64) {
 	log.Printf("\n")
 	log.Printf("%s\n", separator(" By Task "))
 	log.Printf("\n")
@@ -537,11 +535,11 @@ func reportByTask(entries []models.Entry) {
 		var key = task + project
 		consolidated, found := consolidateByTask[key]
 		if found {
-			consolidated.Duration += util.Round(roundToMinutes, entry.Duration)
+			consolidated.Duration += util.Round(roundTo, entry.Duration)
 			consolidateByTask[key] = consolidated
 		} else {
 			var newTask models.Task = models.NewTask(task)
-			newTask.Duration = util.Round(roundToMinutes, entry.Duration)
+			newTask.Duration = util.Round(roundTo, entry.Duration)
 			newTask.AddTaskProperty(constants.PROJECT, entry.Project)
 			newTask.AddTaskProperty(constants.TICKET, entry.GetTicketAsString())
 			consolidateByTask[key] = newTask
@@ -571,9 +569,9 @@ func reportByTask(entries []models.Entry) {
 	// Populate the table.
 	for _, v := range consolidateByTask {
 		if !ticketFound {
-			t.AppendRow(table.Row{v.Task, v.GetProjectsAsString(), secondsToHuman(v.Duration, true)})
+			t.AppendRow(table.Row{v.Task, v.GetProjectsAsString(), secondsToHuman(v.Duration, true, roundTo)})
 		} else {
-			t.AppendRow(table.Row{v.Task, v.GetProjectsAsString(), secondsToHuman(v.Duration, true), jira.FormatJiraUrl(jira.JiraBrowseTicketUrl, v.GetTicketAsString())})
+			t.AppendRow(table.Row{v.Task, v.GetProjectsAsString(), secondsToHuman(v.Duration, true, roundTo), jira.FormatJiraUrl(jira.JiraBrowseTicketUrl, v.GetTicketAsString())})
 		}
 	}
 
@@ -581,19 +579,19 @@ func reportByTask(entries []models.Entry) {
 	log.Println(t.Render())
 
 	// Export table if needed.
-	export("report by task", t)
+	export(cmd, "report by task", t)
 }
 
-func reportTotalWorkAndBreakTime(entries []models.Entry) {
+func reportTotalWorkAndBreakTime(entries []models.Entry, roundTo int64) {
 	var totalWorkDuration int64 = 0
 	var totalBreakDuration int64 = 0
 
 	// Calculate total time worked and total times on break.
 	for _, entry := range entries {
 		if strings.EqualFold(entry.Project, constants.BREAK) {
-			totalBreakDuration += util.Round(roundToMinutes, entry.Duration)
+			totalBreakDuration += util.Round(roundTo, entry.Duration)
 		} else {
-			totalWorkDuration += util.Round(roundToMinutes, entry.Duration)
+			totalWorkDuration += util.Round(roundTo, entry.Duration)
 		}
 	}
 
@@ -608,18 +606,18 @@ func reportTotalWorkAndBreakTime(entries []models.Entry) {
 	// report simply did the conversion for us... that is much better.
 	if viper.GetBool(constants.SPLIT_WORK_FROM_BREAK_TIME) {
 		if totalWorkDuration > constants.SECONDS_PER_DAY {
-			log.Printf("Total Working Time: %s (%s)\n", secondsToHuman(totalWorkDuration, true), secondsToHuman(totalWorkDuration, false))
+			log.Printf("Total Working Time: %s (%s)\n", secondsToHuman(totalWorkDuration, true, roundTo), secondsToHuman(totalWorkDuration, false, roundTo))
 		} else {
-			log.Printf("Total Working Time: %s\n", secondsToHuman(totalWorkDuration, true))
+			log.Printf("Total Working Time: %s\n", secondsToHuman(totalWorkDuration, true, roundTo))
 		}
 
-		log.Printf("  Total Break Time: %s\n", secondsToHuman(totalBreakDuration, true))
+		log.Printf("  Total Break Time: %s\n", secondsToHuman(totalBreakDuration, true, roundTo))
 	} else {
 		var total = totalWorkDuration + totalBreakDuration
 		if totalWorkDuration > constants.SECONDS_PER_DAY {
-			log.Printf("Total Time: %s (%s)\n", secondsToHuman(total, true), secondsToHuman(total, false))
+			log.Printf("Total Time: %s (%s)\n", secondsToHuman(total, true, roundTo), secondsToHuman(total, false, roundTo))
 		} else {
-			log.Printf("Total Time: %s\n", secondsToHuman(total, true))
+			log.Printf("Total Time: %s\n", secondsToHuman(total, true, roundTo))
 		}
 	}
 }
@@ -634,16 +632,12 @@ func SetReportTableStyle(t table.Writer) {
 }
 
 func runReport(cmd *cobra.Command, _ []string) {
-	// Save this so we can use it in other methods.
-	_cmd = cmd
-
 	// See if the user asked to override round.  If no, use the rounding value
 	// from the configuration file.  Otherwise, set the rounding value to 0.
+	var roundTo int64 = 0
 	noRounding, _ := cmd.Flags().GetBool(constants.FLAG_NO_ROUNDING)
 	if !noRounding {
-		roundToMinutes = viper.GetInt64(constants.ROUND_TO_MINUTES)
-	} else {
-		roundToMinutes = 0
+		roundTo = viper.GetInt64(constants.ROUND_TO_MINUTES)
 	}
 
 	currentWeek, _ := cmd.Flags().GetBool(constants.FLAG_CURRENT_WEEK)
@@ -655,10 +649,11 @@ func runReport(cmd *cobra.Command, _ []string) {
 	fromDateStr, _ := cmd.Flags().GetString(constants.FLAG_FROM)
 	toDateStr, _ := cmd.Flags().GetString(constants.FLAG_TO)
 	project, _ := cmd.Flags().GetString(constants.FLAG_PROJECT)
+	interactive, _ := cmd.Flags().GetBool(constants.FLAG_INTERACTIVE)
 
 	// If we are supposed to push report items, validate that we first valid push configuration.
 	if push {
-		pushCredentials = validatePush()
+		pushTargets = loadPushTargets()
 	}
 
 	var now carbon.Carbon = *carbon.Now()
@@ -702,14 +697,56 @@ func runReport(cmd *cobra.Command, _ []string) {
 		log.Printf("\n*****\nStart[%s]\nEnd[%s]\n*****\n", start.ToIso8601String(), end.ToIso8601String())
 	}
 
+	if interactive {
+		runReportBrowser(start, end, project, roundTo)
+		return
+	}
+
 	var startWeek int = start.WeekOfYear()
 	var endWeek int = end.WeekOfYear()
 
 	log.Printf("%s\n", separator(fmt.Sprintf("%s(%d) to %s(%d)", start.ToDateTimeString(), startWeek,
 		end.ToDateTimeString(), endWeek)))
 
-	// Get the unique UIDs between the specified start and end dates.
 	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	var entries []models.Entry = reportEntries(db, start, end, project)
+
+	// Check if the user wants 24h formatted time.
+	if viper.GetBool(constants.DISPLAY_TIME_IN_24H_FORMAT) {
+		startEndTimeFormat = constants.CARBON_START_END_TIME_24H_FORMAT
+	}
+
+	// Run each of the reports, if configured to do so.
+	reportTotalWorkAndBreakTime(entries, roundTo)
+
+	if viper.GetBool(constants.REPORT_BY_PROJECT) {
+		reportByProject(cmd, entries, roundTo)
+	}
+
+	if viper.GetBool(constants.REPORT_BY_TASK) {
+		reportByTask(cmd, entries, roundTo)
+	}
+
+	if viper.GetBool(constants.REPORT_BY_ENTRY) {
+		reportByEntry(cmd, entries, roundTo)
+	}
+
+	if viper.GetBool(constants.REPORT_BY_DAY) {
+		reportByDay(cmd, entries, roundTo)
+	}
+
+	// If the user has asked to push these updates to the server, do so.
+	if push {
+		pushEntries(db, entries, roundTo)
+	}
+}
+
+// reportEntries returns the entries between the start and end dates, for the
+// given project if any, with their durations.  An entry running over midnight
+// is split in two, one for each day, and the hellos and untracked time are
+// left out.
+func reportEntries(db *database.Database, start carbon.Carbon, end carbon.Carbon, project string) []models.Entry {
+	// Get the unique UIDs between the specified start and end dates.
 	var distinctUIDs []database.DistinctUID = db.GetDistinctUIDs(start, end, project)
 
 	if viper.GetBool(constants.DEBUG) {
@@ -816,13 +853,15 @@ func runReport(cmd *cobra.Command, _ []string) {
 		for index, entry := range newEntries {
 			log.Printf("Index[%d] UID[%d], Project[%s], Note[%#v], EntryDatetime[%s], Properties[%#v] Duration[%d or %s]\n",
 				index, entry.Uid, entry.Project, entry.Note, entry.EntryDatetime, entry.GetPropertiesAsString(), entry.Duration,
-				secondsToHuman(entry.Duration, true))
+				secondsToHuman(entry.Duration, true, 0))
 		}
 	}
 
 	var newEntriesWithoutHello []models.Entry
 	for index := range newEntries {
-		if strings.EqualFold(newEntries[index].Project, constants.HELLO) {
+		// Untracked time is neither work nor break, so treat it just like a HELLO.
+		if strings.EqualFold(newEntries[index].Project, constants.HELLO) ||
+			strings.EqualFold(newEntries[index].Project, constants.UNTRACKED) {
 			continue
 		} else {
 			var entry models.Entry = models.NewEntry(newEntries[index].Uid, newEntries[index].Project, newEntries[index].Note, newEntries[index].EntryDatetime)
@@ -837,105 +876,45 @@ func runReport(cmd *cobra.Command, _ []string) {
 		for index, entry := range newEntriesWithoutHello {
 			log.Printf("Index[%d] UID[%d], Project[%s], Note[%#v], EntryDatetime[%s], Properties[%#v] Duration[%d or %s]\n",
 				index, entry.Uid, entry.Project, entry.Note, entry.EntryDatetime, entry.GetPropertiesAsString(), entry.Duration,
-				secondsToHuman(entry.Duration, true))
+				secondsToHuman(entry.Duration, true, 0))
 		}
 	}
 
-	// Check if the user wants 24h formatted time.
-	if viper.GetBool(constants.DISPLAY_TIME_IN_24H_FORMAT) {
-		startEndTimeFormat = constants.CARBON_START_END_TIME_24H_FORMAT
-	}
-
-	// Replace our existing collection of entries with our new collection.
-	entries = newEntriesWithoutHello
-
-	// Run each of the reports, if configured to do so.
-	reportTotalWorkAndBreakTime(entries)
-
-	if viper.GetBool(constants.REPORT_BY_PROJECT) {
-		reportByProject(entries)
-	}
-
-	if viper.GetBool(constants.REPORT_BY_TASK) {
-		reportByTask(entries)
-	}
-
-	if viper.GetBool(constants.REPORT_BY_ENTRY) {
-		reportByEntry(entries)
-	}
-
-	if viper.GetBool(constants.REPORT_BY_DAY) {
-		reportByDay(entries)
-	}
-
-	// If the user has asked to push these updates to the server, do so.
-	if push {
-		pushEntries(db, entries)
-	}
-}
-
-func validatePush() models.Credentials {
-	return rest.ReadCredentials()
+	return newEntriesWithoutHello
 }
 
-type HttpRequest struct {
-	EntryUid int64
-	Request  *http.Request
-}
+func pushEntries(db *database.Database, entries []models.Entry, roundTo int64) {
+	// Collect the unpushed entries, for each target they are pushed to.
+	jobs, err := newPushJobs(pushTargets, entries, roundTo)
+	if err != nil {
+		log.Fatalf("%s: %v\n", color.RedString(constants.FATAL_NORMAL_CASE), err)
+		os.Exit(1)
+	}
 
-func pushEntries(db *database.Database, entries []models.Entry) {
-	var httpRequests []HttpRequest
-
-	// Collect unpushed requests.
-	var payloads []jira.JiraRequest = jira.JiraNewRequests(roundToMinutes, entries)
-
-	// Were there any unpushed requests found? If so, process them.
-	if len(payloads) > 0 {
-		// Transform all the unpushed Jira requests into HTTP requests.
-		for _, payload := range payloads {
-			request, _ := http.NewRequest("POST", jira.FormatJiraUrl(jira.JiraLogWorkToTicketUrl, payload.Ticket), bytes.NewBuffer(payload.Payload))
-			request.Header.Add("Authorization", fmt.Sprintf("Basic %v", rest.BasicAuth(&pushCredentials)))
-			request.Header.Add("Content-Type", "application/json")
-			var httpRequest HttpRequest = HttpRequest{EntryUid: payload.EntryUid, Request: request}
-			httpRequests = append(httpRequests, httpRequest)
+	// Were there any unpushed entries found? If so, process them.
+	if len(jobs) > 0 {
+		var remaining map[int64]int = make(map[int64]int)
+		for _, job := range jobs {
+			remaining[job.entry.Uid]++
 		}
 
-		// If in debug, dump all the HTTP requests to the screen.
+		// If in debug, dump all the requests to the screen.
 		if viper.GetBool(constants.DEBUG) {
-			log.Printf("\n*****\nDumping all HTTP requests...\n*****\n")
-			for _, httpRequest := range httpRequests {
-				log.Printf("Entry UID: %d Request: %v\n", httpRequest.EntryUid, httpRequest.Request)
+			log.Printf("\n*****\nDumping all push requests...\n*****\n")
+			for _, job := range jobs {
+				log.Printf("Entry UID: %d Target: %s Request: %s %s %s\n", job.entry.Uid, job.target.Name, job.request.Method, job.request.URL, job.request.Payload)
 			}
 		}
 
 		// Ask the user if they want to push these changes or not.
-		yesNo := yesNoPrompt("\nThere are %d unpushed entries. Push them to the server?", len(payloads))
+		yesNo := yesNoPrompt("\nThere are %d unpushed entries. Push them to the server?", len(remaining))
 		if yesNo {
 			// Yep...
 			err := util.RunWithSpinner("Pushing entries", func() error {
-				// Attempt to push each entry to the server.
-				for _, httpRequest := range httpRequests {
-					result, err := rest.HTTPClient.Do(httpRequest.Request)
-					if err != nil {
-						return fmt.Errorf("failed to send %v: %v", httpRequest, err)
-					}
-					defer result.Body.Close()
-					body, err := io.ReadAll(result.Body)
-					if err != nil {
-						return fmt.Errorf("failed to read %v", err)
-					}
-
-					if viper.GetBool(constants.DEBUG) {
-						log.Printf("Jira Server responded: %v\n{%q}\n", result.Status, body)
-					}
-
-					// On success, update the entries 'pushed' property.
-					if result.StatusCode == 201 {
-						db.UpdateEntryPushed(httpRequest.EntryUid)
-					} else {
-						var entry models.Entry = db.GetEntry(httpRequest.EntryUid)
-						return fmt.Errorf("for Entry[%s] Jira Server responded: %v\n{%q}",
-							entry.Dump(false, 0), result.Status, body)
+				// Attempt to push each entry to each of its targets.
+				for _, job := range jobs {
+					if _, err := runPushJob(db, job, remaining); err != nil {
+						return fmt.Errorf("for Entry[%s] push target[%s]: %v", job.entry.Dump(false, 0), job.target.Name, err)
 					}
 				}
 
@@ -957,16 +936,16 @@ func pushEntries(db *database.Database, entries []models.Entry) {
 }
 
 func secondsToHumanFloat(inSeconds float64, hmsOnly bool) (result string) {
-	return secondsToHuman(int64(inSeconds), hmsOnly)
+	return secondsToHuman(int64(inSeconds), hmsOnly, 0)
 }
 
-func secondsToHuman(inSeconds int64, hmsOnly bool) (result string) {
+func secondsToHuman(inSeconds int64, hmsOnly bool, roundTo int64) (result string) {
 	// If the duration is zero, this means than the rounded value is less than
 	// the "round to minutes" value, simply show a less than message.
 	var abbreviated bool = viper.GetBool(constants.DISPLAY_HMS_ABBREVIATED)
 
 	if inSeconds == 0 {
-		result = "< " + plural(int(roundToMinutes), "minute")
+		result = "< " + plural(int(roundTo), "minute")
 	} else {
 		if hmsOnly {
 			hours := inSeconds / 3600
@@ -0,0 +1,702 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"khronos/constants"
+	"khronos/internal/database"
+	"khronos/internal/models"
+	"khronos/internal/util"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/viper"
+)
+
+// reportBrowserModel browses a report interactively.  The same entries the
+// report uses are shown by project, by task, by day, or by entry.  A project
+// expands to its tasks and a task to its entries, a day expands to its
+// entries, and enter on a day jumps to its entries in the by entry view.  An
+// entry can be amended in place.
+type reportBrowserModel struct {
+	db      *database.Database
+	start   carbon.Carbon
+	end     carbon.Carbon
+	project string
+	roundTo int64 // minutes the durations are rounded to
+	entries []models.Entry
+
+	view     reportView
+	expanded map[string]bool // expanded groups, by key
+	rows     []reportRow
+
+	cursor int // index into rows
+	width  int
+	height int
+
+	// In place amend.  formEntry is the entry as stored in the database, since
+	// the report splits entries that run over midnight.
+	editing   bool
+	form      [reportFormFieldCount]string
+	formField int
+	formEntry models.Entry
+
+	status string // result of the last amend, or an error
+}
+
+type reportView int
+
+const (
+	reportViewProject reportView = iota
+	reportViewTask
+	reportViewDay
+	reportViewEntry
+	reportViewCount
+)
+
+var reportViewNames = [reportViewCount]string{"By Project", "By Task", "By Day", "By Entry"}
+
+// The fields of the amend form.
+const (
+	reportFormProject = iota
+	reportFormTask
+	reportFormTicket
+	reportFormNote
+	reportFormFieldCount
+)
+
+var reportFormLabels = [reportFormFieldCount]string{constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE,
+	constants.TICKET_NORMAL_CASE, constants.NOTE_NORMAL_CASE}
+
+// reportRow is a row of the browser.  A group row, e.g., a project, has a
+// key; an entry row has the index of its entry instead.
+type reportRow struct {
+	depth    int
+	key      string
+	label    string
+	detail   string
+	duration int64
+	entry    int // index into entries, or -1 for a group
+}
+
+func runReportBrowser(start carbon.Carbon, end carbon.Carbon, project string, roundTo int64) {
+	requireInteractive("the interactive report needs a terminal", constants.EXIT_INPUT_REQUIRED)
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	defer db.Close()
+
+	p := tea.NewProgram(newReportBrowserModel(db, start, end, project, roundTo), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		log.Fatalf("%s: Error running the report browser. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+}
+
+func newReportBrowserModel(db *database.Database, start carbon.Carbon, end carbon.Carbon, project string, roundTo int64) reportBrowserModel {
+	var m reportBrowserModel = reportBrowserModel{
+		db:       db,
+		start:    start,
+		end:      end,
+		project:  project,
+		roundTo:  roundTo,
+		expanded: make(map[string]bool),
+		width:    terminalWidth,
+		height:   24,
+	}
+
+	return m.load()
+}
+
+// load re-reads the entries of the date range and rebuilds the rows.
+func (m reportBrowserModel) load() reportBrowserModel {
+	m.entries = reportEntries(m.db, m.start, m.end, m.project)
+	return m.rebuild()
+}
+
+// rebuild builds the rows of the current view, keeping the cursor on the same
+// group or entry if it is still shown.
+func (m reportBrowserModel) rebuild() reportBrowserModel {
+	var current reportRow
+	if m.cursor >= 0 && m.cursor < len(m.rows) {
+		current = m.rows[m.cursor]
+	}
+
+	switch m.view {
+	case reportViewProject:
+		m.rows = m.projectRows()
+	case reportViewTask:
+		m.rows = m.taskRows()
+	case reportViewDay:
+		m.rows = m.dayRows()
+	default:
+		m.rows = m.entryRows(m.allEntries(), 0)
+	}
+
+	m.cursor = 0
+	for i, row := range m.rows {
+		if row.key == current.key && row.entry == current.entry {
+			m.cursor = i
+			break
+		}
+	}
+
+	return m
+}
+
+func (m reportBrowserModel) allEntries() []int {
+	var indexes []int = make([]int, len(m.entries))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	return indexes
+}
+
+// groupEntries groups the entries, in order, by the given key.  It returns
+// the keys sorted along with the entries of each.
+func (m reportBrowserModel) groupEntries(indexes []int, key func(models.Entry) string) ([]string, map[string][]int) {
+	var groups map[string][]int = make(map[string][]int)
+	for _, i := range indexes {
+		var k string = key(m.entries[i])
+		groups[k] = append(groups[k], i)
+	}
+
+	var keys []string = make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys, groups
+}
+
+// duration returns the rounded duration of the entries, like the report.
+func (m reportBrowserModel) duration(indexes []int) int64 {
+	var total int64 = 0
+	for _, i := range indexes {
+		total += util.Round(m.roundTo, m.entries[i].Duration)
+	}
+
+	return total
+}
+
+// distinct returns the distinct values of the entries, in order of first use.
+func (m reportBrowserModel) distinct(indexes []int, value func(models.Entry) string) string {
+	var values []string
+	var seen map[string]bool = make(map[string]bool)
+	for _, i := range indexes {
+		var v string = value(m.entries[i])
+		if v != constants.EMPTY && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func (m reportBrowserModel) projectRows() []reportRow {
+	var rows []reportRow
+
+	projects, byProject := m.groupEntries(m.allEntries(), func(e models.Entry) string { return e.Project })
+	for _, project := range projects {
+		var indexes []int = byProject[project]
+		var key string = "project:" + project
+		rows = append(rows, reportRow{key: key, label: project, duration: m.duration(indexes), entry: -1,
+			detail: m.distinct(indexes, func(e models.Entry) string { return e.GetTasksAsString() })})
+		if !m.expanded[key] {
+			continue
+		}
+
+		tasks, byTask := m.groupEntries(indexes, func(e models.Entry) string { return e.GetTasksAsString() })
+		for _, task := range tasks {
+			// Breaks and untracked time have no task, so show their entries.
+			if task == constants.EMPTY {
+				rows = append(rows, m.entryRows(byTask[task], 1)...)
+				continue
+			}
+
+			var taskKey string = key + "\x00" + task
+			rows = append(rows, reportRow{depth: 1, key: taskKey, label: task, duration: m.duration(byTask[task]), entry: -1,
+				detail: m.distinct(byTask[task], func(e models.Entry) string { return e.GetTicketAsString() })})
+			if m.expanded[taskKey] {
+				rows = append(rows, m.entryRows(byTask[task], 2)...)
+			}
+		}
+	}
+
+	return rows
+}
+
+func (m reportBrowserModel) taskRows() []reportRow {
+	var rows []reportRow
+
+	tasks, byTask := m.groupEntries(m.allEntries(), func(e models.Entry) string {
+		return e.GetTasksAsString() + constants.TASK_DELIMITER + e.Project
+	})
+	for _, task := range tasks {
+		var indexes []int = byTask[task]
+		var entry models.Entry = m.entries[indexes[0]]
+		var key string = "task:" + task
+		rows = append(rows, reportRow{key: key, label: entry.GetTasksAsString(), detail: entry.Project, duration: m.duration(indexes), entry: -1})
+		if m.expanded[key] {
+			rows = append(rows, m.entryRows(indexes, 1)...)
+		}
+	}
+
+	return rows
+}
+
+func (m reportBrowserModel) dayRows() []reportRow {
+	var rows []reportRow
+
+	days, byDay := m.groupEntries(m.allEntries(), reportDay)
+	for _, day := range days {
+		var indexes []int = byDay[day]
+		var key string = "day:" + day
+		rows = append(rows, reportRow{key: key, label: day + " " + carbon.Parse(day).ToShortWeekString(), duration: m.duration(indexes), entry: -1,
+			detail: m.distinct(indexes, func(e models.Entry) string { return e.Project })})
+		if m.expanded[key] {
+			rows = append(rows, m.entryRows(indexes, 1)...)
+		}
+	}
+
+	return rows
+}
+
+func (m reportBrowserModel) entryRows(indexes []int, depth int) []reportRow {
+	var rows []reportRow
+	for _, i := range indexes {
+		var e models.Entry = m.entries[i]
+
+		var detail string = entryProjectTask(e)
+		if ticket := e.GetTicketAsString(); ticket != constants.EMPTY {
+			detail += " [" + ticket + "]"
+		}
+		if e.Note != constants.EMPTY {
+			detail += " - " + e.Note
+		}
+
+		rows = append(rows, reportRow{depth: depth, label: carbon.Parse(e.EntryDatetime).SetTimezone(carbon.Local).ToDateTimeString(),
+			detail: detail, duration: util.Round(m.roundTo, e.Duration), entry: i})
+	}
+
+	return rows
+}
+
+// reportDay returns the day of an entry, as the by day report groups them.
+func reportDay(e models.Entry) string {
+	return carbon.Parse(e.EntryDatetime).Format(constants.CARBON_DATE_FORMAT)
+}
+
+// parent returns the row index of the group the row belongs to, or -1 if it
+// is a top level row.
+func (m reportBrowserModel) parent(index int) int {
+	for i := index - 1; i >= 0; i-- {
+		if m.rows[i].depth < m.rows[index].depth {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// showView switches to the given view.
+func (m reportBrowserModel) showView(view reportView) reportBrowserModel {
+	m.view = view
+	m.status = ""
+	return m.rebuild()
+}
+
+// showWeek moves the date range to the week containing the given day.
+func (m reportBrowserModel) showWeek(day carbon.Carbon) reportBrowserModel {
+	m.start = *day.Copy()
+	dateRange(&m.start, &m.end)
+	m.status = ""
+	return m.load()
+}
+
+func (m reportBrowserModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m reportBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.updateForm(msg)
+		}
+
+		return m.updateBrowse(msg)
+	}
+
+	return m, nil
+}
+
+func (m reportBrowserModel) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+
+	case "pgup":
+		m.cursor = max(0, m.cursor-m.pageSize())
+
+	case "pgdown":
+		m.cursor = max(0, min(len(m.rows)-1, m.cursor+m.pageSize()))
+
+	case "home", "g":
+		m.cursor = 0
+
+	case "end", "G":
+		m.cursor = max(0, len(m.rows)-1)
+
+	case "tab":
+		return m.showView((m.view + 1) % reportViewCount), nil
+
+	case "shift+tab":
+		return m.showView((m.view + reportViewCount - 1) % reportViewCount), nil
+
+	case "1", "2", "3", "4":
+		view, _ := strconv.Atoi(msg.String())
+		return m.showView(reportView(view - 1)), nil
+
+	case "right", "l", " ":
+		if m.cursor < len(m.rows) && m.rows[m.cursor].entry < 0 {
+			m.expanded[m.rows[m.cursor].key] = true
+			return m.rebuild(), nil
+		}
+
+	case "left", "h":
+		if m.cursor < len(m.rows) {
+			var index int = m.cursor
+			if m.rows[index].entry >= 0 || !m.expanded[m.rows[index].key] {
+				index = m.parent(index)
+			}
+			if index >= 0 {
+				delete(m.expanded, m.rows[index].key)
+				m.cursor = index
+				return m.rebuild(), nil
+			}
+		}
+
+	case "enter":
+		if m.cursor >= len(m.rows) {
+			return m, nil
+		}
+
+		var row reportRow = m.rows[m.cursor]
+		if row.entry >= 0 {
+			return m.openForm(row.entry), nil
+		}
+
+		// A day jumps to its entries.
+		if m.view == reportViewDay {
+			return m.jumpToDay(strings.TrimPrefix(row.key, "day:")), nil
+		}
+
+		m.expanded[row.key] = !m.expanded[row.key]
+		return m.rebuild(), nil
+
+	case "e":
+		if m.cursor < len(m.rows) && m.rows[m.cursor].entry >= 0 {
+			return m.openForm(m.rows[m.cursor].entry), nil
+		}
+
+	case "[":
+		return m.showWeek(*m.start.Copy().SubWeek()), nil
+
+	case "]":
+		return m.showWeek(*m.start.Copy().AddWeek()), nil
+
+	case "t":
+		return m.showWeek(*carbon.Now()), nil
+
+	case "r":
+		m.status = ""
+		return m.load(), nil
+	}
+
+	return m, nil
+}
+
+// jumpToDay switches to the by entry view with the cursor on the day's first
+// entry.
+func (m reportBrowserModel) jumpToDay(day string) reportBrowserModel {
+	m = m.showView(reportViewEntry)
+	for i, row := range m.rows {
+		if reportDay(m.entries[row.entry]) == day {
+			m.cursor = i
+			break
+		}
+	}
+
+	return m
+}
+
+// openForm opens the amend form on the entry, as stored in the database.
+func (m reportBrowserModel) openForm(index int) reportBrowserModel {
+	var entry models.Entry = m.db.GetEntry(m.entries[index].Uid)
+	if entry.Uid == constants.UNKNOWN_UID {
+		m.status = "Error: the entry no longer exists."
+		return m
+	}
+
+	m.editing = true
+	m.formEntry = entry
+	m.formField = reportFormProject
+	m.form = [reportFormFieldCount]string{entry.Project, entry.GetTasksAsString(), entry.GetTicketAsString(), entry.Note}
+	m.status = ""
+	return m
+}
+
+// updateForm handles a key while the amend form is open.  Tab and the arrow
+// keys move between fields, enter saves, and esc cancels.
+func (m reportBrowserModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		m.editing = false
+		m.status = "Entry NOT amended."
+		return m, nil
+
+	case tea.KeyEnter:
+		return m.submitForm(), nil
+
+	case tea.KeyTab, tea.KeyDown:
+		m.formField = (m.formField + 1) % reportFormFieldCount
+		return m, nil
+
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.formField = (m.formField + reportFormFieldCount - 1) % reportFormFieldCount
+		return m, nil
+	}
+
+	m.form[m.formField], _ = editFilterQuery(m.form[m.formField], msg)
+	return m, nil
+}
+
+// submitForm writes the amended entry, through the same change bulk amend
+// applies, and reloads the report.  On error, the form stays open with the
+// error shown.
+func (m reportBrowserModel) submitForm() reportBrowserModel {
+	var change bulkChange = bulkChange{
+		project:    strings.TrimSpace(m.form[reportFormProject]),
+		task:       strings.TrimSpace(m.form[reportFormTask]),
+		ticket:     strings.TrimSpace(m.form[reportFormTicket]),
+		note:       strings.TrimSpace(m.form[reportFormNote]),
+		setProject: true,
+		setTask:    true,
+		setTicket:  true,
+		setNote:    true,
+	}
+
+	if stringUtils.IsBlank(change.project) {
+		m.status = "Error: the project cannot be blank."
+		return m
+	}
+
+	if strings.EqualFold(m.formEntry.Project, constants.HELLO) != strings.EqualFold(change.project, constants.HELLO) {
+		m.status = "Error: an entry cannot be changed to or from " + constants.HELLO + "."
+		return m
+	}
+
+	m.db.UpdateEntries([]models.Entry{change.apply(m.formEntry)})
+
+	m.editing = false
+	m.status = "Entry amended."
+	if m.formEntry.IsPushed() {
+		m.status += "  Warning: it was already pushed."
+	}
+
+	return m.load()
+}
+
+// pageSize returns the number of rows that fit on the screen.
+func (m reportBrowserModel) pageSize() int {
+	return max(3, m.height-10)
+}
+
+func (m reportBrowserModel) View() string {
+	var bold lipgloss.Style = lipgloss.NewStyle().Bold(true)
+	var dim lipgloss.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var views []string
+	for i, name := range reportViewNames {
+		var style lipgloss.Style = lipgloss.NewStyle().Padding(0, 1)
+		if reportView(i) == m.view {
+			style = style.Reverse(true)
+		}
+		views = append(views, style.Render(strconv.Itoa(i+1)+" "+name))
+	}
+
+	var title string = "Report " + m.start.ToDateString() + " to " + m.end.ToDateString()
+	if m.project != constants.EMPTY {
+		title += " for " + m.project
+	}
+
+	var b strings.Builder
+	b.WriteString(bold.Render(title) + "  " + strings.Join(views, " ") + "\n")
+	b.WriteString(m.renderTable())
+	b.WriteString("\n")
+	if len(m.entries) > 0 {
+		b.WriteString(m.totals() + "\n")
+	}
+
+	if m.editing {
+		b.WriteString(m.formView())
+	}
+	if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
+
+	var keys string = "up/down: move - tab/1-4: view - right/space: expand - left: collapse - enter: expand/jump/amend - " +
+		"e: amend - [/]: previous/next week - t: this week - r: reload - q: quit"
+	if m.editing {
+		keys = "tab/up/down: next field - enter: save - esc: cancel"
+	}
+	b.WriteString(dim.Render(keys))
+
+	return b.String()
+}
+
+// totals renders the work and break time of the date range, like the report.
+func (m reportBrowserModel) totals() string {
+	var work, breaks int64
+	for _, e := range m.entries {
+		if strings.EqualFold(e.Project, constants.BREAK) {
+			breaks += util.Round(m.roundTo, e.Duration)
+		} else {
+			work += util.Round(m.roundTo, e.Duration)
+		}
+	}
+
+	return "Total Working Time: " + secondsToHuman(work, true, m.roundTo) + "  Total Break Time: " + secondsToHuman(breaks, true, m.roundTo)
+}
+
+func (m reportBrowserModel) formView() string {
+	var b strings.Builder
+	b.WriteString("Amend " + entryProjectTask(m.formEntry) + " at " + carbon.Parse(m.formEntry.EntryDatetime).ToIso8601String(carbon.Local) + "\n")
+
+	for i, label := range reportFormLabels {
+		var marker string = "  "
+		if i == m.formField {
+			marker = "> "
+		}
+		b.WriteString(marker + label + ": " + m.form[i])
+		if i == m.formField {
+			b.WriteString("_")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderTable renders the rows, scrolled so the cursor stays in view.
+func (m reportBrowserModel) renderTable() string {
+	if len(m.rows) == 0 {
+		return "No entries found.\n"
+	}
+
+	var headers = [reportViewCount]table.Row{
+		{constants.PROJECT_NORMAL_CASE, constants.TASKS_NORMAL_CASE, constants.DURATION_NORMAL_CASE},
+		{constants.TASKS_NORMAL_CASE, constants.PROJECT_NORMAL_CASE, constants.DURATION_NORMAL_CASE},
+		{constants.DATE_NORMAL_CASE, constants.PROJECTS_NORMAL_CASE, constants.DURATION_NORMAL_CASE},
+		{constants.DATE_TIME_NORMAL_CASE, constants.ENTRY_NORMAL_CASE, constants.DURATION_NORMAL_CASE},
+	}
+
+	var rows int = m.pageSize()
+	var first int = max(0, min(m.cursor-rows/2, len(m.rows)-rows))
+	var last int = min(len(m.rows), first+rows)
+
+	var t table.Writer = table.NewWriter()
+	style := table.StyleDefault
+	style.Format.Header = text.FormatUpper
+	t.SetStyle(style)
+	t.SetAllowedRowLength(m.width)
+	t.AppendHeader(headers[m.view])
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 2, WidthMax: max(20, m.width-70), WidthMaxEnforcer: text.Trim},
+		{Number: 3, Align: text.AlignRight},
+	})
+
+	for i := first; i < last; i++ {
+		var row reportRow = m.rows[i]
+
+		var marker string = "  "
+		if row.entry < 0 {
+			marker = "▸ "
+			if m.expanded[row.key] {
+				marker = "▾ "
+			}
+		}
+
+		t.AppendRow(table.Row{strings.Repeat("  ", row.depth) + marker + row.label, row.detail, secondsToHuman(row.duration, true, m.roundTo)})
+	}
+
+	cursor := m.cursor - first
+	t.SetRowPainter(table.RowPainterWithAttributes(func(row table.Row, attr table.RowAttributes) text.Colors {
+		if attr.Number-1 == cursor {
+			return text.Colors{text.BgBlue, text.FgHiWhite}
+		}
+		return nil
+	}))
+
+	return t.Render()
+}
@@ -47,6 +47,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 var cfgFile string
@@ -97,6 +98,8 @@ func init() {
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.PersistentFlags().Bool("help", false, constants.HELP_SHORT_DESCRIPTION)
+	rootCmd.PersistentFlags().BoolVar(&assumeYes, constants.FLAG_YES, false, constants.FLAG_YES_DESCRIPTION)
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, constants.FLAG_NON_INTERACTIVE, false, constants.FLAG_NON_INTERACTIVE_DESCRIPTION)
 
 	terminalWidth = getTerminalWidth()
 }
@@ -156,6 +159,14 @@ func initConfig() {
 	viper.SetDefault(constants.REPORT_BY_ENTRY, true)
 	viper.SetDefault(constants.REPORT_BY_DAY, true)
 
+	// Show the favorites in the order they are configured in, rather than
+	// most used first.
+	viper.SetDefault(constants.FAVORITE_ORDER, constants.FAVORITE_ORDER_CONFIG)
+
+	// Number of recently used project+tasks shown below the favorites when
+	// adding interactively.
+	viper.SetDefault(constants.FAVORITE_RECENT, 5)
+
 	// Require a note.
 	viper.SetDefault(constants.REQUIRE_NOTE, false)
 
@@ -165,6 +176,21 @@ func initConfig() {
 	// Set flag indicating if work and break time should be spit into separate values during reports.
 	viper.SetDefault(constants.SPLIT_WORK_FROM_BREAK_TIME, false)
 
+	// Do not add the ticket in the current git branch's name unless asked to,
+	// and find it as a Jira style key, e.g., ABC-123.
+	viper.SetDefault(constants.TICKET_FROM_BRANCH, false)
+	viper.SetDefault(constants.TICKET_BRANCH_PATTERN, `[A-Z][A-Z0-9_]+-[0-9]+`)
+
+	// Likewise for a ticket mentioned in the note, which may be from any Jira
+	// project unless they are listed.
+	viper.SetDefault(constants.TICKET_FROM_NOTE, false)
+	viper.SetDefault(constants.TICKET_NOTE_PATTERN, `\b[A-Z][A-Z0-9_]+-[0-9]+\b`)
+	viper.SetDefault(constants.TICKET_NOTE_PROJECTS, constants.EMPTY)
+
+	// The post-commit hook records commits for the suggest command, rather
+	// than adding them as entries, unless asked to.
+	viper.SetDefault(constants.COMMIT_HOOK, constants.COMMIT_HOOK_SUGGEST)
+
 	// Set day of the week when determining start of the week.
 	viper.SetDefault(constants.WEEK_START, "Sunday")
 
@@ -185,8 +211,19 @@ func initConfig() {
 		}
 	}
 
+	// Merge any .khronos.yaml files found walking up from the current
+	// directory over the global configuration file.
+	err = mergeConfigLayers()
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
 	// Dump out some debug information.
 	if viper.GetBool(constants.DEBUG) {
+		for _, layer := range configLayers {
+			log.Printf("merged configuration file[%s]\n", layer.path)
+		}
 		log.Printf("%s = [%s]\n", constants.REQUIRE_NOTE, viper.GetString(constants.REQUIRE_NOTE))
 		log.Printf("%s = [%s]\n", constants.WEEK_START, viper.GetString(constants.WEEK_START))
 		log.Printf("%s = [%d]\n", constants.ROUND_TO_MINUTES, viper.GetInt64(constants.ROUND_TO_MINUTES))
@@ -208,14 +245,37 @@ func initConfig() {
 	// Set the default carbon settings. These settings affect each time we ask carbon to create a new instance.
 	carbon.SetTimezone(carbon.UTC)
 
-	if !strings.EqualFold(viper.GetString(constants.PUSH_TYPE), constants.PUSH_TYPE_JIRA) {
-		log.Fatalf("%s: Invalid push type.  Please correct your configuration.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+	// Every push target, or the push settings without any, must be of a
+	// known type.
+	if err := checkPushTargets(); err != nil {
+		log.Fatalf("%s: The %s.  Please correct your configuration.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	if !strings.EqualFold(viper.GetString(constants.FAVORITE_ORDER), constants.FAVORITE_ORDER_CONFIG) &&
+		!strings.EqualFold(viper.GetString(constants.FAVORITE_ORDER), constants.FAVORITE_ORDER_SMART) {
+		log.Fatalf("%s: Invalid favorite order[%s], must be %s or %s.  Please correct your configuration.\n",
+			color.RedString(constants.FATAL_NORMAL_CASE), viper.GetString(constants.FAVORITE_ORDER),
+			constants.FAVORITE_ORDER_CONFIG, constants.FAVORITE_ORDER_SMART)
 		os.Exit(1)
 	}
 
-	if !stringUtils.IsBlank(viper.GetString(constants.PUSH_URL)) {
-		jira.JiraPushUrl = viper.GetString(constants.PUSH_URL)
-		jira.JiraLogWorkToTicketUrl = jira.JiraPushUrl + constants.PUSH_JIRA_V3_URL_TEMPLATE
+	// Tickets are linked to the Jira the entries are pushed to, or the first
+	// of the push targets that is a Jira.
+	var jiraUrl string = viper.GetString(constants.PUSH_URL)
+	if stringUtils.IsBlank(jiraUrl) {
+		if targets, err := readPushTargets(); err == nil {
+			for _, target := range targets {
+				if strings.EqualFold(target.Type, constants.PUSH_TYPE_JIRA) && !stringUtils.IsBlank(target.URL) {
+					jiraUrl = target.URL
+					break
+				}
+			}
+		}
+	}
+
+	if !stringUtils.IsBlank(jiraUrl) {
+		jira.JiraPushUrl = strings.TrimSuffix(jiraUrl, "/")
 		jira.JiraBrowseTicketUrl = jira.JiraPushUrl + "/browse/%s"
 	}
 }
@@ -232,31 +292,26 @@ func writeDefaultFavorites(home string) {
 		os.Exit(1)
 	}
 
-	// Open our configuration file.
-	f, err := os.OpenFile(viper.ConfigFileUsed(), os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		log.Fatalf("%s: Unable to write favorites to configuration file[%s].\n", color.RedString(constants.FATAL_NORMAL_CASE), viper.ConfigFileUsed())
-		os.Exit(1)
-	}
-
-	// Remember to close the file.
-	defer f.Close()
-
-	var lines = []string{
-		"favorites:",
-		"  - favorite: general+training",
-		"  - favorite: general+product development",
-		"  - favorite: general+personal time",
-		"  - favorite: general+holiday",
-		"  - favorite: general+vacation/PTO/Comp",
+	var defaults = []string{
+		"general+training",
+		"general+product development",
+		"general+personal time",
+		"general+holiday",
+		"general+vacation/PTO/Comp",
 	}
 
 	// Write our default favorites to the configuration file.
-	for _, line := range lines {
-		_, err := f.WriteString(line + "\n")
-		if err != nil {
-			log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+	err = updateFavorites(viper.ConfigFileUsed(), func(sequence *yaml.Node) error {
+		for _, favorite := range defaults {
+			var mapping *yaml.Node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			applyFavorite(mapping, Favorite{Favorite: favorite})
+			sequence.Content = append(sequence.Content, mapping)
 		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("%s: Unable to write favorites to configuration file[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), viper.ConfigFileUsed(), err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -0,0 +1,545 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// Rule classifies the entries it matches, e.g., tags every entry whose note
+// mentions standup as a meeting.  Every match setting given must match; the
+// settings in set are then made on the entry.
+type Rule struct {
+	Name  string    `yaml:"name" mapstructure:"name"`
+	Match RuleMatch `yaml:"match" mapstructure:"match"`
+	Set   RuleSet   `yaml:"set" mapstructure:"set"`
+}
+
+// RuleMatch is what a rule matches: the project, any of the tasks, the note,
+// the day of the week, and the time of day the entry ends.
+type RuleMatch struct {
+	Project  string   `yaml:"project" mapstructure:"project"`   // glob
+	Task     string   `yaml:"task" mapstructure:"task"`         // glob
+	Note     string   `yaml:"note" mapstructure:"note"`         // regular expression
+	Weekdays []string `yaml:"weekdays" mapstructure:"weekdays"` // e.g., Saturday
+	Time     string   `yaml:"time" mapstructure:"time"`         // e.g., 18:00-24:00
+}
+
+// RuleSet is what a rule does.  The ticket may use the groups the note
+// matched, e.g., $1, or the whole match, $0.  The tags are added, while the
+// properties, ticket, and task replace any the entry has.
+type RuleSet struct {
+	Ticket     string            `yaml:"ticket" mapstructure:"ticket"`
+	Tags       []string          `yaml:"tags" mapstructure:"tags"`
+	Properties map[string]string `yaml:"properties" mapstructure:"properties"`
+	Task       string            `yaml:"task" mapstructure:"task"`
+}
+
+// ruleKeys, ruleMatchKeys, and ruleSetKeys hold the settings a rule, its match,
+// and its set may have.
+var ruleKeys = map[string]bool{"name": true, "match": true, "set": true}
+var ruleMatchKeys = map[string]bool{"project": true, "task": true, "note": true, "weekdays": true, "time": true}
+var ruleSetKeys = map[string]bool{"ticket": true, "tags": true, "properties": true, "task": true}
+
+// ruleReservedProperties are the properties a rule sets with its own settings,
+// or never sets.
+var ruleReservedProperties = map[string]bool{constants.TASK: true, constants.TICKET: true, constants.PUSHED: true, constants.TAG: true}
+
+var ruleTimeRegex = regexp.MustCompile(`^(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})$`)
+
+// compiledRule is a rule ready to be matched.
+type compiledRule struct {
+	Rule
+	number   int // 1-based position of the rule in the configuration
+	note     *regexp.Regexp
+	weekdays map[time.Weekday]bool
+	from     int // minutes after midnight, -1 for any time
+	to       int
+}
+
+// rulesCmd represents the rules command.
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.RULES_SHORT_DESCRIPTION,
+	Long:  constants.RULES_LONG_DESCRIPTION,
+}
+
+var rulesListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "List the rules, in the order they are applied",
+	Run: func(cmd *cobra.Command, args []string) {
+		runRulesList()
+	},
+}
+
+var rulesApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Args:  cobra.ExactArgs(0),
+	Short: "Apply the rules to existing entries, default is today's",
+	Run: func(cmd *cobra.Command, args []string) {
+		runRulesApply(cmd)
+	},
+}
+
+func init() {
+	rulesApplyCmd.Flags().StringP(constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "Apply the rules to the entries for the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	rulesApplyCmd.Flags().StringP(constants.FLAG_FROM, constants.EMPTY, constants.EMPTY, "Apply the rules to the entries from the given day, in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	rulesApplyCmd.Flags().StringP(constants.FLAG_TO, constants.EMPTY, constants.EMPTY, "Apply the rules to the entries up to the given day, in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	rulesApplyCmd.Flags().BoolP(constants.DRY_RUN, constants.EMPTY, false, "Show the changes the rules would make without making them.")
+	rulesApplyCmd.MarkFlagsMutuallyExclusive(constants.FLAG_DATE, constants.FLAG_FROM)
+	rulesApplyCmd.MarkFlagsMutuallyExclusive(constants.FLAG_DATE, constants.FLAG_TO)
+
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesApplyCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
+
+// loadRules returns the rules, in the order they are applied: the global
+// ones, followed by those of any per-directory configuration files.
+func loadRules() []compiledRule {
+	var rules []Rule
+	err := viper.UnmarshalKey(constants.RULES, &rules)
+	if err != nil {
+		log.Fatalf("%s: Error reading the %s from the configuration. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.RULES, err.Error())
+		os.Exit(1)
+	}
+
+	for _, layer := range configLayers {
+		rules = append(rules, layer.rules...)
+	}
+
+	var compiled []compiledRule
+	for i, r := range rules {
+		c, err := compileRule(r, i+1)
+		if err != nil {
+			log.Fatalf("%s: %s %s.  Please fix it with 'khronos edit'.\n", color.RedString(constants.FATAL_NORMAL_CASE), ruleLabel(r, i+1), err.Error())
+			os.Exit(1)
+		}
+		compiled = append(compiled, c)
+	}
+
+	return compiled
+}
+
+// ruleLabel names the rule in messages.
+func ruleLabel(r Rule, number int) string {
+	if r.Name != constants.EMPTY {
+		return "rule[" + r.Name + "]"
+	}
+
+	return "rule[" + strconv.Itoa(number) + "]"
+}
+
+// compileRule checks the rule, that it matches and sets something and that its
+// note, weekdays, and time are valid, and readies it to be matched.
+func compileRule(r Rule, number int) (compiledRule, error) {
+	var c compiledRule = compiledRule{Rule: r, number: number, from: -1}
+
+	var m RuleMatch = r.Match
+	if m.Project == constants.EMPTY && m.Task == constants.EMPTY && m.Note == constants.EMPTY && len(m.Weekdays) == 0 && m.Time == constants.EMPTY {
+		return c, fmt.Errorf("needs something to match")
+	}
+
+	var s RuleSet = r.Set
+	if s.Ticket == constants.EMPTY && len(s.Tags) == 0 && len(s.Properties) == 0 && s.Task == constants.EMPTY {
+		return c, fmt.Errorf("needs something to set")
+	}
+
+	if m.Note != constants.EMPTY {
+		re, err := regexp.Compile(m.Note)
+		if err != nil {
+			return c, fmt.Errorf("has an invalid note[%s]. %s", m.Note, err.Error())
+		}
+		c.note = re
+	}
+
+	if len(m.Weekdays) > 0 {
+		c.weekdays = make(map[time.Weekday]bool)
+		for _, day := range m.Weekdays {
+			weekday, err := parseWeekday(day)
+			if err != nil {
+				return c, fmt.Errorf("has an invalid weekday[%s], must be a day of the week such as Sunday", day)
+			}
+			c.weekdays[weekday] = true
+		}
+	}
+
+	if m.Time != constants.EMPTY {
+		match := ruleTimeRegex.FindStringSubmatch(strings.TrimSpace(m.Time))
+		if match == nil {
+			return c, fmt.Errorf("has an invalid time[%s], must be a range such as 18:00-24:00", m.Time)
+		}
+
+		var minutes [4]int
+		for i := range minutes {
+			minutes[i], _ = strconv.Atoi(match[i+1])
+		}
+		c.from, c.to = minutes[0]*60+minutes[1], minutes[2]*60+minutes[3]
+		if minutes[1] > 59 || minutes[3] > 59 || c.from > 24*60 || c.to > 24*60 || c.from == c.to {
+			return c, fmt.Errorf("has an invalid time[%s], must be a range such as 18:00-24:00", m.Time)
+		}
+	}
+
+	if s.Ticket != constants.EMPTY && strings.Contains(s.Ticket, "$") && c.note == nil {
+		return c, fmt.Errorf("uses the note's groups in its ticket[%s] but does not match a note", s.Ticket)
+	}
+
+	for name := range s.Properties {
+		if ruleReservedProperties[strings.ToLower(name)] {
+			return c, fmt.Errorf("cannot set the %s property, use its %s setting instead", name, strings.ToLower(name))
+		}
+	}
+
+	if s.Task != constants.EMPTY && strings.TrimSpace(s.Task) == constants.EMPTY {
+		return c, fmt.Errorf("has a blank task")
+	}
+
+	return c, nil
+}
+
+// matches reports whether the rule matches the entry.  Hello entries are
+// never matched, and break and untracked time can be matched as, e.g.,
+// project: break.
+func (c compiledRule) matches(e models.Entry) bool {
+	if strings.EqualFold(e.Project, constants.HELLO) {
+		return false
+	}
+
+	var m RuleMatch = c.Match
+	if m.Project != constants.EMPTY && !globMatch(m.Project, e.Project) && !globMatch(m.Project, strings.TrimPrefix(e.Project, "***")) {
+		return false
+	}
+
+	if m.Task != constants.EMPTY {
+		var found bool
+		for _, p := range e.Properties {
+			if p.Name == constants.TASK && globMatch(m.Task, p.Value) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if c.note != nil && !c.note.MatchString(e.Note) {
+		return false
+	}
+
+	var at time.Time = carbon.Parse(e.EntryDatetime).StdTime().In(time.Local)
+	if c.weekdays != nil && !c.weekdays[at.Weekday()] {
+		return false
+	}
+
+	if c.from >= 0 {
+		var minute int = at.Hour()*60 + at.Minute()
+		if c.from < c.to && (minute < c.from || minute >= c.to) {
+			return false
+		}
+		// A range such as 22:00-06:00 runs over midnight.
+		if c.from > c.to && minute < c.from && minute >= c.to {
+			return false
+		}
+	}
+
+	return true
+}
+
+// apply returns a copy of the entry with the rule's settings made.  Break and
+// untracked time keep having no task or ticket.
+func (c compiledRule) apply(entry models.Entry) models.Entry {
+	var s RuleSet = c.Set
+	var breakOrUntracked bool = isBreakOrUntracked(entry.Project)
+
+	var ticket string = entry.GetTicketAsString()
+	if s.Ticket != constants.EMPTY && !breakOrUntracked {
+		ticket = s.Ticket
+		if c.note != nil {
+			var match []int = c.note.FindStringSubmatchIndex(entry.Note)
+			ticket = string(c.note.ExpandString(nil, s.Ticket, entry.Note, match))
+		}
+	}
+
+	var e models.Entry = models.NewEntry(entry.Uid, entry.Project, entry.Note, entry.EntryDatetime)
+	e.Duration = entry.Duration
+	for _, p := range entry.Properties {
+		switch {
+		case p.Name == constants.TASK && s.Task != constants.EMPTY && !breakOrUntracked:
+		case p.Name == constants.TICKET:
+		case s.Properties[p.Name] != constants.EMPTY:
+		default:
+			e.AddEntryProperty(p.Name, p.Value)
+		}
+	}
+
+	if s.Task != constants.EMPTY && !breakOrUntracked {
+		e.AddEntryProperty(constants.TASK, strings.TrimSpace(s.Task))
+	}
+
+	if !stringUtils.IsBlank(ticket) {
+		e.AddEntryProperty(constants.TICKET, ticket)
+		if !hasProperty(e, constants.PUSHED) {
+			e.AddEntryProperty(constants.PUSHED, constants.EMPTY)
+		}
+	}
+
+	for _, tag := range s.Tags {
+		e.AddEntryProperty(constants.TAG, strings.TrimPrefix(tag, constants.TAG_PREFIX))
+	}
+
+	var names []string = make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if s.Properties[name] != constants.EMPTY {
+			e.AddEntryProperty(name, s.Properties[name])
+		}
+	}
+
+	return e
+}
+
+// hasProperty reports whether the entry has a property with the name.
+func hasProperty(e models.Entry, name string) bool {
+	for _, p := range e.Properties {
+		if p.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyRules applies every rule matching the entry, in order, so a rule sees
+// the changes the rules before it made.  It returns the entry and the rules
+// that changed it.  An entry that was already pushed is left alone.
+func applyRules(rules []compiledRule, entry models.Entry) (models.Entry, []string) {
+	if entry.IsPushed() {
+		return entry, nil
+	}
+
+	var applied []string
+	for _, r := range rules {
+		if !r.matches(entry) {
+			continue
+		}
+
+		var e models.Entry = r.apply(entry)
+		if len(propertyChanges(entry, e)) > 0 {
+			applied = append(applied, ruleLabel(r.Rule, r.number))
+		}
+		entry = e
+	}
+
+	return entry, applied
+}
+
+// applyRulesOnAdd applies the rules to a new, or amended, entry and tells the
+// user which rules changed it.
+func applyRulesOnAdd(entry models.Entry) models.Entry {
+	entry, applied := applyRules(loadRules(), entry)
+	for _, label := range applied {
+		log.Printf("%s: Applied %s.\n", color.HiBlueString(constants.INFO_NORMAL_CASE), label)
+	}
+
+	return entry
+}
+
+// propertyChanges describes how the properties of after differ from before,
+// e.g., "+tag:meeting" and "-task:dev".
+func propertyChanges(before models.Entry, after models.Entry) []string {
+	var count map[string]int = make(map[string]int)
+	for _, p := range before.Properties {
+		count[p.Name+":"+p.Value]--
+	}
+	for _, p := range after.Properties {
+		count[p.Name+":"+p.Value]++
+	}
+
+	var changes []string
+	for _, p := range before.Properties {
+		if key := p.Name + ":" + p.Value; count[key] < 0 {
+			changes = append(changes, "-"+key)
+			count[key] = 0
+		}
+	}
+	for _, p := range after.Properties {
+		if key := p.Name + ":" + p.Value; count[key] > 0 {
+			changes = append(changes, "+"+key)
+			count[key] = 0
+		}
+	}
+
+	return changes
+}
+
+func runRulesList() {
+	var rules []compiledRule = loadRules()
+	if len(rules) == 0 {
+		log.Printf("%s\n", color.YellowString("No rules configured."))
+		return
+	}
+
+	var t table.Writer = table.NewWriter()
+	SetReportTableStyle(t)
+	t.AppendHeader(table.Row{"#", "Name", "Match", "Set"})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 3, WidthMax: 30},
+		{Number: 4, WidthMax: 30},
+	})
+
+	for _, r := range rules {
+		var match []string
+		for _, field := range [][2]string{{"project", r.Match.Project}, {"task", r.Match.Task}, {"note", r.Match.Note},
+			{"weekdays", strings.Join(r.Match.Weekdays, ", ")}, {"time", r.Match.Time}} {
+			if field[1] != constants.EMPTY {
+				match = append(match, field[0]+": "+field[1])
+			}
+		}
+
+		var set []string
+		if r.Set.Task != constants.EMPTY {
+			set = append(set, "task: "+r.Set.Task)
+		}
+		if r.Set.Ticket != constants.EMPTY {
+			set = append(set, "ticket: "+r.Set.Ticket)
+		}
+		if len(r.Set.Tags) > 0 {
+			set = append(set, "tags: "+strings.Join(r.Set.Tags, ", "))
+		}
+		var names []string
+		for name := range r.Set.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			set = append(set, name+": "+r.Set.Properties[name])
+		}
+
+		t.AppendRow(table.Row{r.number, r.Name, strings.Join(match, "\n"), strings.Join(set, "\n")})
+		t.AppendSeparator()
+	}
+
+	log.Println(t.Render())
+}
+
+func runRulesApply(cmd *cobra.Command) {
+	dryRun, _ := cmd.Flags().GetBool(constants.DRY_RUN)
+	start, end := bulkRange(cmd)
+
+	var rules []compiledRule = loadRules()
+	if len(rules) == 0 {
+		log.Printf("%s\n", color.YellowString("No rules configured. Nothing changed."))
+		return
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	defer db.Close()
+
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.DATE_TIME_NORMAL_CASE, "Project+Task", constants.NOTE_NORMAL_CASE, "Rules", "Changes"})
+
+	var changed []models.Entry
+	var pushed int
+	for _, entry := range db.GetEntriesForToday(start, end) {
+		// A pushed entry is left alone, but counted if the rules would change it.
+		if entry.IsPushed() {
+			var unpushed models.Entry = models.NewEntry(entry.Uid, entry.Project, entry.Note, entry.EntryDatetime)
+			for _, p := range entry.Properties {
+				if !models.IsPushProperty(p.Name) {
+					unpushed.AddEntryProperty(p.Name, p.Value)
+				}
+			}
+			if _, applied := applyRules(rules, unpushed); len(applied) > 0 {
+				pushed++
+			}
+			continue
+		}
+
+		e, applied := applyRules(rules, entry)
+		if len(applied) == 0 {
+			continue
+		}
+
+		t.AppendRow(table.Row{carbon.Parse(entry.EntryDatetime).ToIso8601String(carbon.Local), entryProjectTask(entry), entry.Note,
+			strings.Join(applied, "\n"), strings.Join(propertyChanges(entry, e), "\n")})
+		t.AppendSeparator()
+		changed = append(changed, e)
+	}
+
+	if pushed > 0 {
+		log.Printf("%s: Skipped %d already pushed entries the rules would change.\n", color.YellowString("Warning"), pushed)
+	}
+
+	if len(changed) == 0 {
+		log.Printf("%s\n", color.YellowString("The rules change no entries. Nothing changed."))
+		return
+	}
+
+	log.Printf("%s\n", t.Render())
+
+	if dryRun {
+		log.Printf("%s\n", color.YellowString("Dry run, %d entries NOT changed.", len(changed)))
+		return
+	}
+
+	yesNo := yesNoPrompt("\nChange these %d entries?", len(changed))
+	if yesNo {
+		// All the entries are written in a single transaction.
+		db.UpdateEntriesProperties(changed)
+		log.Printf("%s\n", color.GreenString("Entries changed."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Entries NOT changed."))
+	}
+}
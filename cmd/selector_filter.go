@@ -0,0 +1,264 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// The selectors' type-to-filter.  The query is split into words and every word
+// must fuzzy match, i.e., appear in order but not necessarily next to each
+// other, in one of a row's fields.  Rows are ranked by how well they match;
+// ties keep the order the rows were given in.
+
+// highlightColors marks the matched characters.  The row painter re-applies
+// the cursor row's colors after each highlight, so highlights stay readable
+// on the cursor row.
+var highlightColors = text.Colors{text.Bold, text.Underline, text.FgHiYellow}
+
+// fuzzyMatch reports whether the runes of term appear, in order and ignoring
+// case, in s.  The score favours runs of consecutive characters and matches
+// at the start of words.  positions are the rune indexes of the matched
+// characters in s.
+func fuzzyMatch(term string, s string) (int, []int, bool) {
+	var t []rune = lowerRunes(term)
+	var r []rune = lowerRunes(s)
+
+	if len(t) == 0 {
+		return 0, nil, true
+	}
+
+	var bestScore int = math.MinInt
+	var bestPositions []int
+	for start := range r {
+		if r[start] != t[0] {
+			continue
+		}
+
+		var positions []int = []int{start}
+		var score int = fuzzyCharScore(r, start, -1)
+		for i := start + 1; i < len(r) && len(positions) < len(t); i++ {
+			if r[i] == t[len(positions)] {
+				score += fuzzyCharScore(r, i, positions[len(positions)-1])
+				positions = append(positions, i)
+			}
+		}
+
+		// If the rest of the term does not match from here, it will not match
+		// from any later start either.
+		if len(positions) < len(t) {
+			break
+		}
+
+		if score > bestScore {
+			bestScore = score
+			bestPositions = positions
+		}
+	}
+
+	if bestPositions == nil {
+		return 0, nil, false
+	}
+
+	return bestScore, bestPositions, true
+}
+
+// fuzzyCharScore scores the match of r[i] given the position of the previous
+// matched character, or -1 if it is the first.
+func fuzzyCharScore(r []rune, i int, previous int) int {
+	var score int = 1
+
+	if i == 0 || !(unicode.IsLetter(r[i-1]) || unicode.IsDigit(r[i-1])) {
+		score += 3
+	}
+
+	if previous >= 0 {
+		if i == previous+1 {
+			score += 4
+		} else {
+			score -= min(i-previous-1, 3)
+		}
+	}
+
+	return score
+}
+
+func lowerRunes(s string) []rune {
+	var r []rune = []rune(s)
+	for i := range r {
+		r[i] = unicode.ToLower(r[i])
+	}
+
+	return r
+}
+
+// fuzzyMatchFields matches every word of the query against the fields.  Each
+// word counts with its best matching field.  positions holds the matched rune
+// indexes per field, for highlighting.
+func fuzzyMatchFields(query string, fields []string) (int, [][]int, bool) {
+	var total int
+	var positions [][]int = make([][]int, len(fields))
+
+	for _, term := range strings.Fields(query) {
+		var best int = -1
+		var bestScore int
+		var bestPositions []int
+		for i, field := range fields {
+			score, matched, ok := fuzzyMatch(term, field)
+			if ok && (best < 0 || score > bestScore) {
+				best = i
+				bestScore = score
+				bestPositions = matched
+			}
+		}
+
+		if best < 0 {
+			return 0, nil, false
+		}
+
+		total += bestScore
+		positions[best] = append(positions[best], bestPositions...)
+	}
+
+	return total, positions, true
+}
+
+// filterRows returns the rows, given as indexes in display order, that match
+// the query, best match first, along with each row's highlight positions.  A
+// blank query keeps every row in the given order.
+func filterRows(query string, order []int, fields func(int) []string) ([]int, [][][]int) {
+	if strings.TrimSpace(query) == "" {
+		return order, make([][][]int, len(order))
+	}
+
+	type match struct {
+		row       int
+		score     int
+		positions [][]int
+	}
+
+	var matches []match
+	for _, row := range order {
+		if score, positions, ok := fuzzyMatchFields(query, fields(row)); ok {
+			matches = append(matches, match{row, score, positions})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	var rows []int = make([]int, len(matches))
+	var highlights [][][]int = make([][][]int, len(matches))
+	for i, m := range matches {
+		rows[i] = m.row
+		highlights[i] = m.positions
+	}
+
+	return rows, highlights
+}
+
+// highlightField returns the field with its matched characters highlighted.
+// positions may be nil, i.e., nothing is highlighted.
+func highlightField(s string, positions [][]int, field int) string {
+	if field >= len(positions) || len(positions[field]) == 0 {
+		return s
+	}
+
+	var matched map[int]bool = make(map[int]bool)
+	for _, p := range positions[field] {
+		matched[p] = true
+	}
+
+	var b strings.Builder
+	var run []rune
+	for i, r := range []rune(s) {
+		if matched[i] {
+			run = append(run, r)
+			continue
+		}
+
+		if len(run) > 0 {
+			b.WriteString(highlightColors.Sprint(string(run)))
+			run = nil
+		}
+		b.WriteRune(r)
+	}
+	if len(run) > 0 {
+		b.WriteString(highlightColors.Sprint(string(run)))
+	}
+
+	return b.String()
+}
+
+// filterLine renders the filter query, with a cursor while it is being typed,
+// and how many rows match.
+func filterLine(filtering bool, query string, matches int) string {
+	var cursor string
+	if filtering {
+		cursor = "_"
+	}
+
+	var line string = "Filter: " + query + cursor + "  (" + strconv.Itoa(matches) + " matching)"
+	if matches == 0 {
+		line = "Filter: " + query + cursor + "  (nothing matches)"
+	}
+
+	return line + "\n"
+}
+
+// editFilterQuery applies a key typed while filtering to the query.  It
+// reports false if the key does not edit the query.
+func editFilterQuery(query string, msg tea.KeyMsg) (string, bool) {
+	switch msg.Type {
+	case tea.KeyBackspace:
+		var runes []rune = []rune(query)
+		if len(runes) > 0 {
+			query = string(runes[:len(runes)-1])
+		}
+		return query, true
+
+	case tea.KeySpace:
+		return query + " ", true
+
+	case tea.KeyRunes:
+		return query + string(msg.Runes), true
+	}
+
+	return query, false
+}
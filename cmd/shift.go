@@ -0,0 +1,221 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"khronos/constants"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/ijt/go-anytime"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// shiftCmd represents the shift command.
+var shiftCmd = &cobra.Command{
+	Use:   "shift",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.SHIFT_SHORT_DESCRIPTION,
+	Long:  constants.SHIFT_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runShift(cmd, args)
+	},
+}
+
+func init() {
+	shiftCmd.Flags().StringP(constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "Shift the entries for the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	shiftCmd.Flags().StringP(constants.FLAG_BY, constants.EMPTY, constants.EMPTY, "Duration to shift the entries by, e.g., '+1h' or '-30m'.")
+	shiftCmd.Flags().StringP(constants.FLAG_AFTER, constants.EMPTY, constants.EMPTY, "Only shift the entries at or after this Natural Language Time, e.g., '13:00'.")
+	shiftCmd.MarkFlagRequired(constants.FLAG_BY)
+	rootCmd.AddCommand(shiftCmd)
+}
+
+func runShift(cmd *cobra.Command, _ []string) {
+	givenDate, _ := cmd.Flags().GetString(constants.FLAG_DATE)
+	byStr, _ := cmd.Flags().GetString(constants.FLAG_BY)
+	afterStr, _ := cmd.Flags().GetString(constants.FLAG_AFTER)
+
+	by, err := time.ParseDuration(byStr)
+	if err != nil || by == 0 {
+		log.Fatalf("%s: Failed parsing 'by' duration[%s].  Please use a non-zero duration such as '+1h' or '-30m'.\n",
+			color.RedString(constants.FATAL_NORMAL_CASE), byStr)
+		os.Exit(1)
+	}
+
+	var day carbon.Carbon = *carbon.Now()
+	if !stringUtils.IsEmpty(givenDate) {
+		day = *carbon.Parse(givenDate)
+		if day.Error != nil {
+			log.Fatalf("%s: Invalid date[%s].  Please use %s format.\n", color.RedString(constants.FATAL_NORMAL_CASE), givenDate, constants.DATE_FORMAT_YYYY_MM_DD)
+			os.Exit(1)
+		}
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	var entries []models.Entry
+
+	// The day's HELLO stays put, it marks the start of the day.
+	for _, e := range db.GetEntriesForToday(*day.Copy().StartOfDay(), *day.Copy().EndOfDay()) {
+		if !strings.EqualFold(e.Project, constants.HELLO) {
+			entries = append(entries, e)
+		}
+	}
+
+	// If the --after flag was entered, only shift the entries at or after that time.
+	if !stringUtils.IsEmpty(afterStr) {
+		var reference time.Time = day.Copy().SetTimezone(carbon.Local).StdTime()
+		afterTime, err := anytime.Parse(afterStr, reference)
+		if err != nil {
+			log.Fatalf("%s: Failed parsing 'after' time. %s.  For natural date examples see https://github.com/ijt/go-anytime\n",
+				color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		var after carbon.Carbon = *carbon.CreateFromStdTime(afterTime)
+		var filtered []models.Entry
+		for _, e := range entries {
+			if !carbon.Parse(e.EntryDatetime).Lt(&after) {
+				filtered = append(filtered, e)
+			}
+		}
+		entries = filtered
+	}
+
+	if len(entries) == 0 {
+		log.Printf("%s\n", color.YellowString("No entries found."))
+		return
+	}
+
+	var shifted []models.Entry = shiftEntries(entries, by)
+	checkShiftNeighbours(db, entries, shifted)
+
+	// Create a table to show the old verses new values.
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, "Old", "New", constants.PUSHED_NORMAL_CASE})
+	var pushed int
+	for i, e := range entries {
+		var pushedMark string = constants.EMPTY
+		if e.IsPushed() {
+			pushedMark = "yes"
+			pushed++
+		}
+
+		t.AppendRow(table.Row{e.Project, e.GetTasksAsString(),
+			carbon.Parse(e.EntryDatetime).ToIso8601String(carbon.Local),
+			carbon.Parse(shifted[i].EntryDatetime).ToIso8601String(carbon.Local), pushedMark})
+	}
+
+	// Render the table.
+	log.Println(t.Render())
+
+	// The worklogs of pushed entries would no longer match their times.
+	if pushed > 0 {
+		log.Fatalf("%s: %d of the entries were already pushed and cannot be shifted.  Nothing shifted.\n",
+			color.RedString(constants.FATAL_NORMAL_CASE), pushed)
+		os.Exit(1)
+	}
+
+	// Ask the user if they want to commit these changes or not.
+	yesNo := yesNoPrompt("\nShift %d entries by %s?", len(entries), by.String())
+	if yesNo {
+		db.UpdateEntriesDatetime(shifted)
+		log.Printf("%s\n", color.GreenString("Entries shifted."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Entries NOT shifted."))
+	}
+}
+
+// shiftEntries returns a copy of the entries with each date/time moved by the
+// given duration.
+func shiftEntries(entries []models.Entry, by time.Duration) []models.Entry {
+	var result []models.Entry
+
+	for _, e := range entries {
+		var shifted models.Entry = models.NewEntry(e.Uid, e.Project, e.Note,
+			carbon.Parse(e.EntryDatetime).AddSeconds(int(by.Seconds())).ToIso8601String(carbon.UTC))
+		shifted.Properties = e.Properties
+		result = append(result, shifted)
+	}
+
+	return result
+}
+
+// checkShiftNeighbours makes sure the shifted run of entries does not move past
+// the entries just before or after it, which would reorder the timeline, and
+// that the entry just after it, whose duration the shift changes, was not
+// already pushed.  The entry just before the run is usually the day's HELLO,
+// which never moves.
+func checkShiftNeighbours(db *database.Database, entries []models.Entry, shifted []models.Entry) {
+	var previous models.Entry = db.GetEntryBefore(entries[0].EntryDatetime)
+	if previous.Uid != constants.UNKNOWN_UID {
+		var previousTime carbon.Carbon = *carbon.Parse(previous.EntryDatetime)
+		if !carbon.Parse(shifted[0].EntryDatetime).Gt(&previousTime) {
+			log.Fatalf("%s: The shifted entries would move before the %s entry at %s.  Nothing shifted.\n",
+				color.RedString(constants.FATAL_NORMAL_CASE), neighbourName(previous), previousTime.ToIso8601String(carbon.Local))
+			os.Exit(1)
+		}
+	}
+
+	var next models.Entry = db.GetEntryAfter(entries[len(entries)-1].EntryDatetime)
+	if next.Uid != constants.UNKNOWN_UID {
+		var nextTime carbon.Carbon = *carbon.Parse(next.EntryDatetime)
+		if !carbon.Parse(shifted[len(shifted)-1].EntryDatetime).Lt(&nextTime) {
+			log.Fatalf("%s: The shifted entries would move after the %s entry at %s.  Nothing shifted.\n",
+				color.RedString(constants.FATAL_NORMAL_CASE), neighbourName(next), nextTime.ToIso8601String(carbon.Local))
+			os.Exit(1)
+		}
+
+		if next.IsPushed() {
+			log.Fatalf("%s: The %s entry at %s was already pushed, and shifting the entries before it would change its duration.  Nothing shifted.\n",
+				color.RedString(constants.FATAL_NORMAL_CASE), neighbourName(next), nextTime.ToIso8601String(carbon.Local))
+			os.Exit(1)
+		}
+	}
+}
+
+func neighbourName(entry models.Entry) string {
+	if strings.EqualFold(entry.Project, constants.HELLO) || strings.EqualFold(entry.Project, constants.BREAK) {
+		return entry.Project
+	}
+
+	return entryProjectTask(entry)
+}
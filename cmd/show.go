@@ -34,6 +34,7 @@ import (
 	"khronos/constants"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/dromara/carbon/v2"
 	"github.com/fatih/color"
@@ -63,18 +64,29 @@ var statistics bool
 var unpushed bool
 
 type Configuration struct {
-	DatabaseFilename string     `yaml:"database_file"`
-	WeekStart        string     `yaml:"week_start"`
-	RoundToMinutes   int        `yaml:"round_to_minutes"`
-	Debug            bool       `yaml:"debug"`
-	Favorites        []Favorite `yaml:"favorites"`
+	DatabaseFilename string      `yaml:"database_file"`
+	WeekStart        string      `yaml:"week_start"`
+	RoundToMinutes   int         `yaml:"round_to_minutes"`
+	Debug            bool        `yaml:"debug"`
+	Favorites        []Favorite  `yaml:"favorites"`
+	Workspaces       []Workspace `yaml:"workspaces"`
+	Rules            []Rule      `yaml:"rules"`
 }
 
 type Favorite struct {
-	Favorite    string `yaml:"favorite"`
-	Description string `yaml:"description"`
-	Ticket      string `yaml:"ticket"`
-	RequireNote bool   `default:"false" yaml:"require_note"`
+	Favorite    string            `yaml:"favorite"`
+	Alias       string            `yaml:"alias"`
+	Description string            `yaml:"description"`
+	Ticket      string            `yaml:"ticket"`
+	RequireNote bool              `default:"false" yaml:"require_note"`
+	Note        string            `yaml:"note"`
+	Tags        []string          `yaml:"tags"`
+	Properties  map[string]string `yaml:"properties"`
+	Duration    string            `yaml:"duration"`
+
+	// Layer is the per-directory configuration file the favorite is from,
+	// or empty if it is from the global one.
+	Layer string `yaml:"-"`
 }
 
 func init() {
@@ -129,6 +141,12 @@ func loadFavorites() []Favorite {
 		os.Exit(1)
 	}
 
+	// The favorites of any per-directory configuration files follow the
+	// global ones, so the global favorites keep their numbers.
+	for _, layer := range configLayers {
+		config.Favorites = append(config.Favorites, layer.favorites...)
+	}
+
 	return config.Favorites
 }
 
@@ -145,24 +163,99 @@ func showFavorites() {
 	showFavoritesTable(favs)
 }
 
+// favoriteColumns records which of the optional columns the favorites table
+// needs, so the table and the interactive selector lay out the same columns.
+type favoriteColumns struct {
+	alias       bool
+	description bool
+	ticket      bool
+	layer       bool
+}
+
+func newFavoriteColumns(favs []Favorite) favoriteColumns {
+	var columns favoriteColumns
+	for _, f := range favs {
+		columns.alias = columns.alias || len(f.Alias) > 0
+		columns.description = columns.description || len(f.Description) > 0
+		columns.ticket = columns.ticket || len(f.Ticket) > 0
+		columns.layer = columns.layer || len(f.Layer) > 0
+	}
+
+	return columns
+}
+
+func (c favoriteColumns) header() table.Row {
+	var row table.Row = table.Row{"#", constants.PROJECT_TASK}
+	if c.alias {
+		row = append(row, constants.ALIAS)
+	}
+	if c.description {
+		row = append(row, constants.DESCRIPTION)
+	}
+	if c.ticket {
+		row = append(row, constants.URL)
+	}
+	if c.layer {
+		row = append(row, "From")
+	}
+
+	return append(row, constants.REQUIRE_NOTE_WITH_ASTERISK)
+}
+
+// row returns the favorite's row.  highlights are the characters matched by
+// the selector's filter, per favoriteSearchFields, or nil.
+func (c favoriteColumns) row(num int, f Favorite, highlights [][]int) table.Row {
+	var row table.Row = table.Row{num, highlightField(f.Favorite, highlights, 0)}
+	if c.alias {
+		row = append(row, highlightField(f.Alias, highlights, 1))
+	}
+	if c.description {
+		row = append(row, highlightField(f.Description, highlights, 2))
+	}
+	if c.ticket {
+		row = append(row, jira.FormatJiraUrl(jira.JiraBrowseTicketUrl, highlightField(f.Ticket, highlights, 3)))
+	}
+	if c.layer {
+		row = append(row, favoriteLayerLabel(f))
+	}
+
+	return append(row, f.RequireNote)
+}
+
+// favoriteLayerLabel returns where the favorite is from: global, or the
+// directory of its per-directory configuration file.
+func favoriteLayerLabel(f Favorite) string {
+	if f.Layer == constants.EMPTY {
+		return "global"
+	}
+
+	return configLayerLabel(f.Layer)
+}
+
+// favoriteSearchFields returns the fields the selector's filter matches
+// against, in the order row highlights them.
+func favoriteSearchFields(f Favorite) []string {
+	return []string{f.Favorite, f.Alias, f.Description, f.Ticket}
+}
+
+// requireNoteColumn returns the 1-based number of the require note column,
+// which is always last.
+func (c favoriteColumns) requireNoteColumn() int {
+	return len(c.header())
+}
+
 // showFavoritesTable is the original non-interactive rendering, preserved as the
 // fallback for non-terminal output.
 func showFavoritesTable(favs []Favorite) {
 	var t table.Writer = table.NewWriter()
 
-	log.Printf("Favorites found in configuration file[%s]:\n\n", viper.ConfigFileUsed())
-
-	var ticketFound bool = false
-	var descriptionFound bool = false
-	for _, f := range favs {
-		if len(f.Ticket) > 0 {
-			ticketFound = true
-		}
-
-		if len(f.Description) > 0 {
-			descriptionFound = true
-		}
+	var files []string = []string{viper.ConfigFileUsed()}
+	for _, layer := range configLayers {
+		files = append(files, layer.path)
 	}
+	log.Printf("Favorites found in configuration file[%s]:\n\n", strings.Join(files, "], ["))
+
+	var columns favoriteColumns = newFavoriteColumns(favs)
 
 	style := table.StyleDefault
 	style.Format.Header = text.FormatUpper
@@ -170,21 +263,7 @@ func showFavoritesTable(favs []Favorite) {
 	style.Size.WidthMax = terminalWidth
 	t.SetStyle(style)
 
-	var requiredNoteColumn int
-
-	if descriptionFound && ticketFound {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.DESCRIPTION, constants.URL, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 5
-	} else if descriptionFound {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.DESCRIPTION, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 4
-	} else if ticketFound {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.URL, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 4
-	} else {
-		t.AppendHeader(table.Row{"#", constants.PROJECT_TASK, constants.REQUIRE_NOTE_WITH_ASTERISK})
-		requiredNoteColumn = 3
-	}
+	t.AppendHeader(columns.header())
 
 	// Set a couple of the columns to fixed values.
 	t.SetColumnConfigs([]table.ColumnConfig{
@@ -194,7 +273,7 @@ func showFavoritesTable(favs []Favorite) {
 			WidthMax: 3,
 		},
 		{
-			Number:   requiredNoteColumn,
+			Number:   columns.requireNoteColumn(),
 			WidthMin: 13,
 			WidthMax: 13,
 		},
@@ -203,16 +282,7 @@ func showFavoritesTable(favs []Favorite) {
 	// Add all the favorites to the table. The "#" column is 1-based to match
 	// the interactive selector and the --favorite flag.
 	for i, f := range favs {
-		num := i + 1
-		if descriptionFound && ticketFound {
-			t.AppendRow(table.Row{num, f.Favorite, f.Description, jira.FormatJiraUrl(jira.JiraBrowseTicketUrl, f.Ticket), f.RequireNote})
-		} else if descriptionFound {
-			t.AppendRow(table.Row{num, f.Favorite, f.Description, f.RequireNote})
-		} else if ticketFound {
-			t.AppendRow(table.Row{num, f.Favorite, jira.FormatJiraUrl(jira.JiraBrowseTicketUrl, f.Ticket), f.RequireNote})
-		} else {
-			t.AppendRow(table.Row{num, f.Favorite, f.RequireNote})
-		}
+		t.AppendRow(columns.row(i+1, f, nil))
 	}
 
 	log.Println(t.Render())
@@ -237,7 +307,7 @@ func showStatistics() {
 	t.AppendRow(table.Row{"First Entry", firstEntry.Dump(false, 0)})
 	t.AppendRow(table.Row{"Last Entry", lastEntry.Dump(false, 0)})
 	t.AppendRow(table.Row{"Total Records", count})
-	t.AppendRow(table.Row{"Total Duration", secondsToHuman(diff, true)})
+	t.AppendRow(table.Row{"Total Duration", secondsToHuman(diff, true, 0)})
 	log.Println(t.Render())
 }
 
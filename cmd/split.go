@@ -0,0 +1,272 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/ijt/go-anytime"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// splitCmd represents the split command.
+var splitCmd = &cobra.Command{
+	Use:   "split",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.SPLIT_SHORT_DESCRIPTION,
+	Long:  constants.SPLIT_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSplit(cmd, args)
+	},
+}
+
+func init() {
+	splitCmd.Flags().BoolP(constants.FLAG_TODAY, constants.EMPTY, false, "List all the entries for today.")
+	splitCmd.Flags().StringP(constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "List all the entries for the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	splitCmd.Flags().Int64P(constants.FLAG_UID, constants.EMPTY, constants.UNKNOWN_UID, "Split the entry with the given uid.")
+	splitCmd.MarkFlagsMutuallyExclusive(constants.FLAG_TODAY, constants.FLAG_DATE, constants.FLAG_UID)
+	splitCmd.Flags().StringArrayP(constants.FLAG_POINT, constants.EMPTY, []string{}, constants.FLAG_POINT_DESCRIPTION)
+	splitCmd.Flags().StringArrayP(constants.FLAG_AS, constants.EMPTY, []string{}, constants.FLAG_AS_DESCRIPTION)
+	splitCmd.MarkFlagRequired(constants.FLAG_POINT)
+	rootCmd.AddCommand(splitCmd)
+}
+
+func runSplit(cmd *cobra.Command, _ []string) {
+	points, _ := cmd.Flags().GetStringArray(constants.FLAG_POINT)
+	segments, _ := cmd.Flags().GetStringArray(constants.FLAG_AS)
+
+	if len(segments) > 0 && len(segments) != len(points)+1 {
+		log.Fatalf("%s: %d split point(s) make %d segments, but %d '%s' value(s) were given.\n",
+			color.RedString(constants.FATAL_NORMAL_CASE), len(points), len(points)+1, len(segments), constants.FLAG_AS)
+		os.Exit(1)
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	entry, ok := chooseEntry(cmd, db, "Select an entry to split")
+	if !ok {
+		log.Printf("%s\n", color.YellowString("No entry split."))
+		return
+	}
+
+	if strings.EqualFold(entry.Project, constants.HELLO) {
+		log.Fatalf("%s: A %s entry cannot be split.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.HELLO)
+		os.Exit(1)
+	}
+
+	if entry.IsPushed() {
+		log.Fatalf("%s: The entry was already pushed and cannot be split.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+		os.Exit(1)
+	}
+
+	// The entry runs from the end of the previous entry, or midnight if there is
+	// none, to its own date/time.
+	var end carbon.Carbon = *carbon.Parse(entry.EntryDatetime)
+	var start carbon.Carbon = *end.Copy().StartOfDay()
+	var previous models.Entry = db.GetEntryBefore(entry.EntryDatetime)
+	if previous.Uid != constants.UNKNOWN_UID {
+		start = *carbon.Parse(previous.EntryDatetime)
+	}
+
+	splitTimes := parseSplitPoints(points, start, end)
+
+	log.Printf("%s", "Splitting...\n"+entry.Dump(true, constants.INDENT_AMOUNT)+"\n\n")
+
+	// Build the new entries, one per segment.  Each segment ends at its split
+	// point, and the last segment ends where the original entry ended.
+	var newEntries []models.Entry
+	var segmentStart carbon.Carbon = start
+	for i := 0; i <= len(splitTimes); i++ {
+		var segmentEnd carbon.Carbon = end
+		if i < len(splitTimes) {
+			segmentEnd = splitTimes[i]
+		}
+
+		var segment string
+		if len(segments) > 0 {
+			segment = segments[i]
+		} else {
+			segment = promptForSegment(i+1, entry)
+		}
+
+		newEntry := newSegmentEntry(segment, entry, segmentEnd)
+		newEntry.Duration = segmentStart.DiffAbsInSeconds(&segmentEnd)
+		newEntries = append(newEntries, newEntry)
+
+		segmentStart = segmentEnd
+	}
+
+	log.Printf("\n")
+
+	// Create a table to show the old verses new values.
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{"", constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, constants.NOTE_NORMAL_CASE,
+		constants.TICKET_NORMAL_CASE, "Start", "End"})
+	t.AppendRow(table.Row{"Old", entry.Project, entry.GetTasksAsString(), entry.Note, entry.GetTicketAsString(),
+		start.ToIso8601String(carbon.Local), end.ToIso8601String(carbon.Local)})
+	t.AppendSeparator()
+
+	segmentStart = start
+	for i, e := range newEntries {
+		var segmentEnd carbon.Carbon = *carbon.Parse(e.EntryDatetime)
+		t.AppendRow(table.Row{fmt.Sprintf("New %d", i+1), e.Project, e.GetTasksAsString(), e.Note, e.GetTicketAsString(),
+			segmentStart.ToIso8601String(carbon.Local), segmentEnd.ToIso8601String(carbon.Local)})
+		segmentStart = segmentEnd
+	}
+
+	// Render the table.
+	log.Println(t.Render())
+
+	// Ask the user if they want to commit these changes or not.
+	yesNo := yesNoPrompt("\nCommit these changes?")
+	if yesNo {
+		db.ReplaceEntries([]int64{entry.Uid}, newEntries)
+		log.Printf("%s\n", color.GreenString("Entry split."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Entry NOT split."))
+	}
+}
+
+// parseSplitPoints converts each split point into a date/time.  A point is
+// either a duration measured from the previous point (or the start of the
+// entry) or a natural language time on the entry's day.  The points must be in
+// order and fall strictly inside the entry.
+func parseSplitPoints(points []string, start carbon.Carbon, end carbon.Carbon) []carbon.Carbon {
+	var result []carbon.Carbon
+	var previous carbon.Carbon = start
+	var reference time.Time = end.Copy().SetTimezone(carbon.Local).StdTime()
+
+	for _, point := range points {
+		var splitTime carbon.Carbon
+
+		duration, err := time.ParseDuration(point)
+		if err == nil {
+			splitTime = *previous.Copy().AddSeconds(int(duration.Seconds()))
+		} else {
+			pointTime, err := anytime.Parse(point, reference)
+			if err != nil {
+				log.Fatalf("%s: Failed parsing split point[%s]. %s.  For natural date examples see https://github.com/ijt/go-anytime\n",
+					color.RedString(constants.FATAL_NORMAL_CASE), point, err.Error())
+				os.Exit(1)
+			}
+
+			splitTime = *carbon.CreateFromStdTime(pointTime)
+		}
+
+		if !splitTime.Gt(&previous) || !splitTime.Lt(&end) {
+			log.Fatalf("%s: Split point[%s] resolves to %s, which is not between %s and %s.\n", color.RedString(constants.FATAL_NORMAL_CASE),
+				point, splitTime.ToIso8601String(carbon.Local), previous.ToIso8601String(carbon.Local), end.ToIso8601String(carbon.Local))
+			os.Exit(1)
+		}
+
+		result = append(result, splitTime)
+		previous = splitTime
+	}
+
+	return result
+}
+
+// promptForSegment asks the user for the project+task and note of a segment,
+// defaulting to the values of the entry being split.
+func promptForSegment(number int, entry models.Entry) string {
+	log.Printf("Segment %d\n", number)
+	projectTask := prompt(constants.PROJECT_TASK, entryProjectTask(entry))
+	segmentNote := prompt(constants.NOTE_NORMAL_CASE, entry.Note)
+
+	if stringUtils.IsEmpty(segmentNote) {
+		return projectTask
+	}
+
+	return projectTask + ": " + segmentNote
+}
+
+// newSegmentEntry builds an entry from a 'project+task[: note]' segment.  The
+// segment keeps the original entry's other properties, e.g., its ticket and
+// tags, but break segments have neither tasks nor tickets.
+func newSegmentEntry(segment string, original models.Entry, segmentEnd carbon.Carbon) models.Entry {
+	projectTask, segmentNote, _ := strings.Cut(segment, ":")
+	projectTask = strings.TrimSpace(projectTask)
+	segmentNote = strings.TrimSpace(segmentNote)
+
+	var entry models.Entry
+	if strings.EqualFold(projectTask, constants.BREAK) {
+		entry = models.NewEntry(constants.UNKNOWN_UID, constants.BREAK, segmentNote, segmentEnd.ToIso8601String(carbon.UTC))
+	} else {
+		project, tasks, err := parseProjectTask(projectTask)
+		if err != nil {
+			log.Fatalf("%s: Unable to parsing 'project+task'.  %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		entry = models.NewEntry(constants.UNKNOWN_UID, project, segmentNote, segmentEnd.ToIso8601String(carbon.UTC))
+		for _, task := range tasks {
+			entry.AddEntryProperty(constants.TASK, task)
+		}
+	}
+
+	for _, p := range original.Properties {
+		if p.Name == constants.TASK {
+			continue
+		}
+		if isBreakOrUntracked(entry.Project) && (p.Name == constants.TICKET || models.IsPushProperty(p.Name)) {
+			continue
+		}
+		entry.AddEntryProperty(p.Name, p.Value)
+	}
+
+	return entry
+}
+
+// entryProjectTask rebuilds the 'project+task[+task...]' string of an entry.
+func entryProjectTask(entry models.Entry) string {
+	var result string = entry.Project
+
+	for _, p := range entry.Properties {
+		if strings.EqualFold(p.Name, constants.TASK) {
+			result += constants.TASK_DELIMITER + p.Value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"khronos/constants"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// statusCmd represents the status command.
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Args:  cobra.ExactArgs(0),
+	Short: constants.STATUS_SHORT_DESCRIPTION,
+	Long:  constants.STATUS_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runStatus()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+func runStatus() {
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+
+	var files []string = []string{viper.ConfigFileUsed()}
+	for _, layer := range configLayers {
+		files = append(files, layer.path)
+	}
+	t.AppendRow(table.Row{"Configuration", strings.Join(files, "\n")})
+
+	dir, _ := os.Getwd()
+	t.AppendRow(table.Row{"Directory", dir})
+
+	if gitDir, found := findGitDir(dir); found {
+		var remotes []string
+		for _, url := range gitRemoteURLs(gitDir) {
+			remotes = append(remotes, normalizeRemoteURL(url))
+		}
+		if len(remotes) == 0 {
+			remotes = append(remotes, "no remotes")
+		}
+		var repository string = filepath.Dir(gitDir)
+		if branch, onBranch := gitBranch(gitDir); onBranch {
+			repository += " on branch " + branch
+		}
+		t.AppendRow(table.Row{"Repository", repository + "\n" + strings.Join(remotes, "\n")})
+	}
+
+	var workspaces []Workspace = loadWorkspaces()
+	if ws, found := matchWorkspace(workspaces, dir); found {
+		t.AppendRow(table.Row{"Workspace", workspaceSummary(ws)})
+	} else if len(workspaces) > 0 {
+		t.AppendRow(table.Row{"Workspace", "none of the " + strings.TrimSpace(plural(len(workspaces), "workspace")) + " match"})
+	} else {
+		t.AppendRow(table.Row{"Workspace", "none configured"})
+	}
+
+	if pending := len(readPendingCommits()); pending > 0 {
+		t.AppendRow(table.Row{"Pending", strings.TrimSpace(plural(pending, "commit")) + " recorded by the post-commit hook, see suggest"})
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	var entry models.Entry = db.GetLastEntry()
+	if entry.Uid == constants.UNKNOWN_UID {
+		t.AppendRow(table.Row{"Last Entry", "none"})
+	} else {
+		var at *carbon.Carbon = carbon.Parse(entry.EntryDatetime).SetTimezone(carbon.Local)
+		var last string = entry.Project
+		for _, property := range entry.Properties {
+			if property.Name == constants.TASK {
+				last += constants.TASK_DELIMITER + property.Value
+			}
+		}
+		if entry.Note != constants.EMPTY {
+			last += "  " + entry.Note
+		}
+		var ago string = " ago"
+		if at.Gt(carbon.Now()) {
+			ago = " from now"
+		}
+		last += "\n" + at.ToDateTimeString() + ", " + secondsToHumanFloat(carbon.Now().DiffAbsInDuration(at).Seconds(), false) + ago
+		t.AppendRow(table.Row{"Last Entry", last})
+	}
+
+	log.Println(t.Render())
+}
+
+// workspaceSummary describes the matching workspace rule and what it sets.
+func workspaceSummary(ws workspaceMatch) string {
+	var lines []string = []string{"workspace[" + strconv.Itoa(ws.Number) + "] matched by " + ws.Reason}
+	if ws.Project != constants.EMPTY {
+		var project string = ws.Project
+		if ws.ProjectTask() != constants.EMPTY {
+			project = ws.ProjectTask()
+		}
+		lines = append(lines, "project: "+project)
+	}
+	if ws.TicketPattern != constants.EMPTY {
+		lines = append(lines, "ticket pattern: "+ws.TicketPattern)
+	}
+
+	var favorites []string
+	for _, f := range loadFavorites() {
+		if ws.inScope(f) {
+			favorites = append(favorites, f.Favorite)
+		}
+	}
+	if len(favorites) > 0 {
+		lines = append(lines, "favorites: "+strings.Join(favorites, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
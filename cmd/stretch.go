@@ -113,6 +113,17 @@ func yesNoPrompt(format string, args ...any) bool {
 	fmt.Printf(format, args...)
 	fmt.Print(" Y/N (yes/no) > ")
 
+	// With --yes, every confirmation is answered for the user.
+	if assumeYes {
+		fmt.Println("yes")
+		return true
+	}
+
+	if !isInteractive() {
+		fmt.Println()
+	}
+	requireInteractive("a confirmation is required; use --yes to confirm", constants.EXIT_CONFIRMATION_REQUIRED)
+
 	s, _ := readLine(stdinReader)
 	s = strings.ToLower(strings.TrimSpace(s))
 
@@ -0,0 +1,429 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// suggestCmd represents the suggest command.
+var suggestCmd = &cobra.Command{
+	Use:   "suggest [repository...]",
+	Short: constants.SUGGEST_SHORT_DESCRIPTION,
+	Long:  constants.SUGGEST_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSuggest(cmd, args)
+	},
+}
+
+func init() {
+	suggestCmd.Flags().StringP(constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "Suggest entries for the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	suggestCmd.Flags().StringArrayP(constants.FLAG_REPOS, constants.EMPTY, nil, "A git repository, or a glob of them, e.g., '~/src/*', to read commits from. Specify once per glob. Default is the current directory's repository.")
+	suggestCmd.Flags().StringP(constants.FLAG_AUTHOR, constants.EMPTY, constants.EMPTY, "Read the commits of this author rather than each repository's configured user.email.")
+	rootCmd.AddCommand(suggestCmd)
+}
+
+// gitCommit is a commit read from a repository's log.
+type gitCommit struct {
+	hash    string
+	dir     string
+	at      time.Time
+	branch  string
+	subject string
+}
+
+// suggestion is a proposed entry, made from one or more consecutive commits to
+// the same repository for the same project+task and ticket.
+type suggestion struct {
+	dir         string
+	at          time.Time
+	projectTask string
+	ticket      string
+	note        string
+	commits     int
+	selected    bool
+}
+
+func runSuggest(cmd *cobra.Command, args []string) {
+	givenDate, _ := cmd.Flags().GetString(constants.FLAG_DATE)
+	patterns, _ := cmd.Flags().GetStringArray(constants.FLAG_REPOS)
+	author, _ := cmd.Flags().GetString(constants.FLAG_AUTHOR)
+
+	// The shell usually expands a glob before Khronos sees it, so the
+	// repositories may also be given as arguments.
+	patterns = append(patterns, args...)
+
+	var day carbon.Carbon = *carbon.Now()
+	var until carbon.Carbon = *carbon.Now()
+	if givenDate != constants.EMPTY {
+		day = parseBulkDate(givenDate)
+		until = *day.Copy().EndOfDay()
+		if until.Gt(carbon.Now()) {
+			until = *carbon.Now()
+		}
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	defer db.Close()
+
+	var entries []models.Entry = db.GetEntriesForToday(*day.Copy().StartOfDay(), *day.Copy().EndOfDay())
+	var hello models.Entry
+	var found bool
+	for _, e := range entries {
+		if strings.EqualFold(e.Project, constants.HELLO) {
+			hello, found = e, true
+			break
+		}
+	}
+	if !found {
+		log.Fatalf("%s: There is no %s on %s, so there is nothing to suggest entries after.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.HELLO, day.ToDateString())
+		os.Exit(1)
+	}
+
+	// The time up to the day's last entry is already accounted for, so only
+	// the commits after it are suggested.
+	var after carbon.Carbon = *carbon.Parse(entries[len(entries)-1].EntryDatetime)
+	var helloTime carbon.Carbon = *carbon.Parse(hello.EntryDatetime)
+
+	// The commits recorded by the post-commit hook are suggested along with
+	// those read from the repositories.
+	var pending []gitCommit = readPendingCommits()
+
+	var repos []string = suggestRepos(patterns)
+	if len(repos) == 0 && len(pending) == 0 {
+		log.Fatalf("%s: The current directory is not in a git repository.  Please use --%s to choose the repositories.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.FLAG_REPOS)
+		os.Exit(1)
+	}
+
+	var candidates []gitCommit = pending
+	for _, dir := range repos {
+		repoCommits, err := readGitCommits(dir, author, helloTime.StdTime())
+		if err != nil {
+			log.Printf("%s: Skipping repository[%s]. %s\n", color.YellowString("Warning"), dir, err.Error())
+			continue
+		}
+		candidates = append(candidates, repoCommits...)
+	}
+
+	var commits []gitCommit
+	var covered int
+	var seen map[string]bool = make(map[string]bool)
+	for _, c := range candidates {
+		// The same commit may be in more than one of the repositories, e.g.,
+		// in a clone and its work tree, as well as pending.
+		if seen[c.hash] || !c.at.After(helloTime.StdTime()) || c.at.After(until.StdTime()) {
+			continue
+		}
+		seen[c.hash] = true
+
+		if !c.at.After(after.StdTime()) {
+			covered++
+			continue
+		}
+		commits = append(commits, c)
+	}
+
+	// The pending commits up to the day's last entry need not be suggested
+	// again.
+	var startOfDay carbon.Carbon = *day.Copy().StartOfDay()
+	defer func() {
+		err := removePendingCommits(func(c gitCommit) bool {
+			return !c.at.Before(startOfDay.StdTime()) && !c.at.After(after.StdTime())
+		})
+		if err != nil {
+			log.Printf("%s: %s\n", color.YellowString("Warning"), err.Error())
+		}
+	}()
+
+	if covered > 0 {
+		log.Printf("%s: Skipped %s already covered by the entries up to %s.\n", color.HiBlueString(constants.INFO_NORMAL_CASE),
+			strings.TrimSpace(plural(covered, "commit")), after.ToIso8601String(carbon.Local))
+	}
+
+	if len(commits) == 0 {
+		log.Printf("%s\n", color.YellowString("No commits found to suggest entries from. Nothing added."))
+		return
+	}
+
+	var suggestions []suggestion = buildSuggestions(commits, loadWorkspaces())
+
+	requireInteractive("reviewing the suggested entries needs a terminal", constants.EXIT_SELECTION_REQUIRED)
+	suggestions, accepted, err := reviewSuggestions(suggestions, after, until)
+	if err != nil {
+		log.Fatalf("%s: Error running the suggestion review. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	var chosen []models.Entry
+	if accepted {
+		var rules []compiledRule = loadRules()
+		for _, s := range suggestions {
+			if s.selected {
+				// Classify the entry with the rules in the configuration.
+				e, _ := applyRules(rules, s.entry())
+				chosen = append(chosen, e)
+			}
+		}
+	}
+
+	if len(chosen) == 0 {
+		log.Printf("%s\n", color.YellowString("Nothing added."))
+		return
+	}
+
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.DATE_TIME_NORMAL_CASE, constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE,
+		constants.TICKET_NORMAL_CASE, constants.NOTE_NORMAL_CASE, constants.DURATION_NORMAL_CASE})
+
+	var previous carbon.Carbon = after
+	for _, e := range chosen {
+		var at carbon.Carbon = *carbon.Parse(e.EntryDatetime)
+		t.AppendRow(table.Row{at.ToIso8601String(carbon.Local), e.Project, e.GetTasksAsString(), e.GetTicketAsString(), e.Note,
+			formatSeconds(previous.DiffAbsInSeconds(&at))})
+		previous = at
+	}
+
+	log.Printf("You are about to add these entries\n\n%s\n", t.Render())
+
+	yesNo := yesNoPrompt("\nAdd these %d entries?", len(chosen))
+	if yesNo {
+		// All the entries are written in a single transaction.
+		db.InsertNewEntries(chosen)
+		after = *carbon.Parse(chosen[len(chosen)-1].EntryDatetime)
+		log.Printf("%s\n", color.GreenString("Entries added."))
+	} else {
+		log.Printf("%s\n", color.YellowString("Nothing added."))
+	}
+}
+
+// suggestRepos expands the repository globs into the git repositories they
+// match, in order and without duplicates.  With none, it is the repository of
+// the current directory, if any.
+func suggestRepos(patterns []string) []string {
+	if len(patterns) == 0 {
+		dir, _ := os.Getwd()
+		if gitDir, found := findGitDir(dir); found {
+			return []string{filepath.Dir(gitDir)}
+		}
+		return nil
+	}
+
+	var repos []string
+	var seen map[string]bool = make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(expandHome(pattern))
+		if err != nil {
+			log.Fatalf("%s: Invalid repository glob[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), pattern, err.Error())
+			os.Exit(1)
+		}
+
+		var found bool
+		for _, match := range matches {
+			dir, err := filepath.Abs(match)
+			if err != nil {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+				continue
+			}
+
+			found = true
+			if !seen[dir] {
+				seen[dir] = true
+				repos = append(repos, dir)
+			}
+		}
+
+		if !found {
+			log.Printf("%s: No git repositories found matching[%s].\n", color.YellowString("Warning"), pattern)
+		}
+	}
+
+	if len(repos) == 0 {
+		log.Fatalf("%s: No git repositories found.\n", color.RedString(constants.FATAL_NORMAL_CASE))
+		os.Exit(1)
+	}
+
+	return repos
+}
+
+// readGitCommits returns the commits made to any local or remote tracking
+// branch of the repository, by author or, if empty, the repository's
+// user.email, since the given time.  Unlike the rest of the git support, this
+// runs git, since reading the log means reading packed objects.
+func readGitCommits(dir string, author string, since time.Time) ([]gitCommit, error) {
+	if author == constants.EMPTY {
+		out, _ := exec.Command("git", "-C", dir, "config", "user.email").Output()
+		author = strings.TrimSpace(string(out))
+		if author == constants.EMPTY {
+			return nil, fmt.Errorf("it has no user.email configured, use --%s", constants.FLAG_AUTHOR)
+		}
+	}
+
+	out, err := exec.Command("git", "-C", dir, "log", "--branches", "--remotes", "--source", "--no-merges", "--fixed-strings",
+		"--author="+author, "--since="+since.Format(time.RFC3339), gitLogFormat).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	return parseGitLog(dir, string(out)), nil
+}
+
+// gitLogFormat is the format parseGitLog reads: the hash, author date, ref the
+// commit was reached from, and subject, separated by unit separators.
+const gitLogFormat = "--format=%H%x1f%aI%x1f%S%x1f%s"
+
+// parseGitLog returns the commits in the output of git log run with
+// gitLogFormat.
+func parseGitLog(dir string, out string) []gitCommit {
+	var commits []gitCommit
+	for _, line := range strings.Split(out, "\n") {
+		var fields []string = strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+
+		at, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+
+		var branch string = strings.TrimPrefix(fields[2], "refs/heads/")
+		if remote, found := strings.CutPrefix(fields[2], "refs/remotes/"); found {
+			_, branch, _ = strings.Cut(remote, "/")
+		}
+
+		commits = append(commits, gitCommit{hash: fields[0], dir: dir, at: at, branch: branch, subject: strings.TrimSpace(fields[3])})
+	}
+
+	return commits
+}
+
+// buildSuggestions turns the commits, in time order, into suggestions.  The
+// project+task comes from the workspace matching the repository, and the
+// ticket from the branch or, failing that, the commit's subject.  Consecutive
+// commits to the same repository for the same project+task and ticket become
+// one suggestion ending at the last of them.
+func buildSuggestions(commits []gitCommit, workspaces []Workspace) []suggestion {
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].at.Before(commits[j].at) })
+
+	var suggestions []suggestion
+	for _, c := range commits {
+		var projectTask, pattern string = constants.EMPTY, viper.GetString(constants.TICKET_BRANCH_PATTERN)
+		if ws, found := matchWorkspace(workspaces, c.dir); found {
+			projectTask = ws.ProjectTask()
+			if projectTask == constants.EMPTY {
+				projectTask = ws.Project
+			}
+			if ws.TicketPattern != constants.EMPTY {
+				pattern = ws.TicketPattern
+			}
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Fatalf("%s: Invalid ticket pattern[%s]. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), pattern, err.Error())
+			os.Exit(1)
+		}
+
+		ticket, found := branchTicket(c.branch, re)
+		if !found {
+			ticket, _ = branchTicket(c.subject, re)
+		}
+
+		if n := len(suggestions); n > 0 {
+			var last *suggestion = &suggestions[n-1]
+			if last.dir == c.dir && last.projectTask == projectTask && last.ticket == ticket {
+				last.at = c.at
+				last.commits++
+				if !strings.Contains("; "+last.note+"; ", "; "+c.subject+"; ") {
+					last.note += "; " + c.subject
+				}
+				continue
+			}
+		}
+
+		suggestions = append(suggestions, suggestion{dir: c.dir, at: c.at, projectTask: projectTask, ticket: ticket, note: c.subject, commits: 1})
+	}
+
+	// Only the complete suggestions are selected to start with.
+	for i := range suggestions {
+		suggestions[i].selected = suggestions[i].complete()
+	}
+
+	return suggestions
+}
+
+// complete reports whether the suggestion has a well formed project+task, so
+// it can be added.
+func (s suggestion) complete() bool {
+	_, _, err := parseProjectTask(s.projectTask)
+	return err == nil
+}
+
+// entry returns the entry to add for the suggestion.  A ticket is added as
+// not yet pushed.
+func (s suggestion) entry() models.Entry {
+	project, tasks, _ := parseProjectTask(s.projectTask)
+
+	var entry models.Entry = models.NewEntry(constants.UNKNOWN_UID, project, s.note, carbon.CreateFromStdTime(s.at).ToIso8601String(carbon.UTC))
+	for _, task := range tasks {
+		entry.AddEntryProperty(constants.TASK, task)
+	}
+	if s.ticket != constants.EMPTY {
+		entry.AddEntryProperty(constants.TICKET, s.ticket)
+		entry.AddEntryProperty(constants.PUSHED, constants.EMPTY)
+	}
+
+	return entry
+}
@@ -0,0 +1,389 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"khronos/constants"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dromara/carbon/v2"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// suggestReviewModel reviews the suggested entries before they are added.
+// Each suggestion can be selected or not, and edited in place; enter accepts
+// the selected ones.
+type suggestReviewModel struct {
+	suggestions []suggestion
+	after       carbon.Carbon // the day's last entry, suggestions must be after it
+	until       carbon.Carbon // suggestions must not be after it
+
+	cursor int // index into suggestions
+	width  int
+	height int
+
+	editing   bool
+	form      [suggestFormFieldCount]string
+	formField int
+
+	accepted bool
+	status   string // result of the last edit, or an error
+}
+
+// The fields of the edit form.
+const (
+	suggestFormTime = iota
+	suggestFormProjectTask
+	suggestFormTicket
+	suggestFormNote
+	suggestFormFieldCount
+)
+
+var suggestFormLabels = [suggestFormFieldCount]string{constants.TIME_NORMAL_CASE, "Project+Task",
+	constants.TICKET_NORMAL_CASE, constants.NOTE_NORMAL_CASE}
+
+// reviewSuggestions shows the suggestions for review and returns them, as
+// edited, along with whether they were accepted.
+func reviewSuggestions(suggestions []suggestion, after carbon.Carbon, until carbon.Carbon) ([]suggestion, bool, error) {
+	var m suggestReviewModel = suggestReviewModel{
+		suggestions: suggestions,
+		after:       after,
+		until:       until,
+		width:       terminalWidth,
+		height:      24,
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return nil, false, err
+	}
+
+	m = final.(suggestReviewModel)
+	return m.suggestions, m.accepted, nil
+}
+
+func (m suggestReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m suggestReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.updateForm(msg)
+		}
+
+		return m.updateReview(msg)
+	}
+
+	return m, nil
+}
+
+func (m suggestReviewModel) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = constants.EMPTY
+
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.suggestions)-1 {
+			m.cursor++
+		}
+
+	case "home", "g":
+		m.cursor = 0
+
+	case "end", "G":
+		m.cursor = max(0, len(m.suggestions)-1)
+
+	case " ", "x":
+		m.suggestions[m.cursor] = m.toggle(m.suggestions[m.cursor], !m.suggestions[m.cursor].selected)
+
+	case "a":
+		// Select all the complete suggestions, unless they already are.
+		var all bool = true
+		for _, s := range m.suggestions {
+			if s.complete() && !s.selected {
+				all = false
+			}
+		}
+		for i := range m.suggestions {
+			m.suggestions[i] = m.toggle(m.suggestions[i], !all)
+		}
+
+	case "e":
+		return m.openForm(), nil
+
+	case "enter":
+		return m.accept()
+	}
+
+	return m, nil
+}
+
+// toggle selects, or unselects, the suggestion.  An incomplete suggestion
+// cannot be selected until its project+task is edited.
+func (m *suggestReviewModel) toggle(s suggestion, selected bool) suggestion {
+	if selected && !s.complete() {
+		m.status = "Error: edit the project+task of the suggestion at " + suggestionTime(s) + " before selecting it."
+		return s
+	}
+
+	s.selected = selected
+	return s
+}
+
+// accept ends the review, unless two selected suggestions are at the same
+// time, since they would become entries with the same date/time.
+func (m suggestReviewModel) accept() (tea.Model, tea.Cmd) {
+	var previous *suggestion
+	for i := range m.suggestions {
+		if !m.suggestions[i].selected {
+			continue
+		}
+		if previous != nil && previous.at.Equal(m.suggestions[i].at) {
+			m.cursor = i
+			m.status = "Error: two selected suggestions are at " + suggestionTime(m.suggestions[i]) + ", edit the time of one."
+			return m, nil
+		}
+		previous = &m.suggestions[i]
+	}
+
+	m.accepted = true
+	return m, tea.Quit
+}
+
+func (m suggestReviewModel) openForm() suggestReviewModel {
+	var s suggestion = m.suggestions[m.cursor]
+	m.editing = true
+	m.formField = suggestFormProjectTask
+	m.form = [suggestFormFieldCount]string{suggestionTime(s), s.projectTask, s.ticket, s.note}
+	return m
+}
+
+// updateForm handles a key while the edit form is open.  Tab and the arrow
+// keys move between fields, enter saves, and esc cancels.
+func (m suggestReviewModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		m.editing = false
+		m.status = "Suggestion NOT changed."
+		return m, nil
+
+	case tea.KeyEnter:
+		return m.submitForm(), nil
+
+	case tea.KeyTab, tea.KeyDown:
+		m.formField = (m.formField + 1) % suggestFormFieldCount
+		return m, nil
+
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.formField = (m.formField + suggestFormFieldCount - 1) % suggestFormFieldCount
+		return m, nil
+	}
+
+	m.form[m.formField], _ = editFilterQuery(m.form[m.formField], msg)
+	return m, nil
+}
+
+// submitForm saves the edited suggestion, which is selected, and keeps the
+// suggestions in time order.  On error, the form stays open with the error
+// shown.
+func (m suggestReviewModel) submitForm() suggestReviewModel {
+	var s suggestion = m.suggestions[m.cursor]
+
+	var clock string = strings.TrimSpace(m.form[suggestFormTime])
+	if clock != suggestionTime(s) {
+		var date string = carbon.CreateFromStdTime(s.at).SetTimezone(carbon.Local).ToDateString()
+		at, err := parseLogLineTime(date, clock)
+		if err != nil {
+			m.status = "Error: " + err.Error() + "."
+			return m
+		}
+		if !at.After(m.after.StdTime()) || at.After(m.until.StdTime()) {
+			m.status = "Error: the time must be after " + m.after.ToIso8601String(carbon.Local) + " and not after " + m.until.ToIso8601String(carbon.Local) + "."
+			return m
+		}
+		s.at = at
+	}
+
+	s.projectTask = strings.TrimSpace(m.form[suggestFormProjectTask])
+	if _, _, err := parseProjectTask(s.projectTask); err != nil {
+		m.status = "Error: " + err.Error() + "."
+		return m
+	}
+
+	s.ticket = strings.TrimSpace(m.form[suggestFormTicket])
+	s.note = strings.TrimSpace(m.form[suggestFormNote])
+	s.selected = true
+	m.suggestions[m.cursor] = s
+
+	sort.SliceStable(m.suggestions, func(i, j int) bool { return m.suggestions[i].at.Before(m.suggestions[j].at) })
+	for i := range m.suggestions {
+		if m.suggestions[i].at.Equal(s.at) && m.suggestions[i].dir == s.dir {
+			m.cursor = i
+		}
+	}
+
+	m.editing = false
+	m.status = "Suggestion changed."
+	return m
+}
+
+// selectedCount returns the number of selected suggestions.
+func (m suggestReviewModel) selectedCount() int {
+	var count int
+	for _, s := range m.suggestions {
+		if s.selected {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (m suggestReviewModel) View() string {
+	var bold lipgloss.Style = lipgloss.NewStyle().Bold(true)
+	var dim lipgloss.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var b strings.Builder
+	b.WriteString(bold.Render("Suggested entries after "+m.after.ToIso8601String(carbon.Local)) +
+		"  " + strconv.Itoa(m.selectedCount()) + " of " + strconv.Itoa(len(m.suggestions)) + " selected\n")
+	b.WriteString(m.renderTable())
+	b.WriteString("\n")
+
+	if m.editing {
+		b.WriteString(m.formView())
+	}
+	if m.status != constants.EMPTY {
+		b.WriteString(m.status + "\n")
+	}
+
+	var keys string = "up/down: move - space: select - a: select all - e: edit - enter: accept - q: cancel"
+	if m.editing {
+		keys = "tab/up/down: next field - enter: save - esc: cancel"
+	}
+	b.WriteString(dim.Render(keys))
+
+	return b.String()
+}
+
+func (m suggestReviewModel) formView() string {
+	var s suggestion = m.suggestions[m.cursor]
+
+	var b strings.Builder
+	b.WriteString("Edit the suggestion from " + filepath.Base(s.dir) + "\n")
+
+	for i, label := range suggestFormLabels {
+		var marker string = "  "
+		if i == m.formField {
+			marker = "> "
+		}
+		b.WriteString(marker + label + ": " + m.form[i])
+		if i == m.formField {
+			b.WriteString("_")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderTable renders the suggestions, scrolled so the cursor stays in view.
+func (m suggestReviewModel) renderTable() string {
+	var rows int = max(3, m.height-10)
+	var first int = max(0, min(m.cursor-rows/2, len(m.suggestions)-rows))
+	var last int = min(len(m.suggestions), first+rows)
+
+	var t table.Writer = table.NewWriter()
+	style := table.StyleDefault
+	style.Format.Header = text.FormatUpper
+	t.SetStyle(style)
+	t.SetAllowedRowLength(m.width)
+	t.AppendHeader(table.Row{"", constants.TIME_NORMAL_CASE, "Repository", "Project+Task", constants.TICKET_NORMAL_CASE, constants.NOTE_NORMAL_CASE})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 6, WidthMax: max(20, m.width-75), WidthMaxEnforcer: text.Trim},
+	})
+
+	for i := first; i < last; i++ {
+		var s suggestion = m.suggestions[i]
+
+		var mark string = "[ ]"
+		if s.selected {
+			mark = "[x]"
+		}
+
+		var projectTask string = s.projectTask
+		if !s.complete() {
+			projectTask += "?"
+		}
+
+		t.AppendRow(table.Row{mark, suggestionTime(s), filepath.Base(s.dir), projectTask, s.ticket, s.note})
+	}
+
+	cursor := m.cursor - first
+	t.SetRowPainter(table.RowPainterWithAttributes(func(row table.Row, attr table.RowAttributes) text.Colors {
+		if attr.Number-1 == cursor {
+			return text.Colors{text.BgBlue, text.FgHiWhite}
+		}
+		return nil
+	}))
+
+	return t.Render()
+}
+
+// suggestionTime returns the suggestion's local time of day.
+func suggestionTime(s suggestion) string {
+	return s.at.In(time.Local).Format("15:04:05")
+}
@@ -0,0 +1,263 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"khronos/constants"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"khronos/internal/database"
+	"khronos/internal/models"
+)
+
+// validateTimeline checks the date/time of a new or amended entry against its
+// neighbours and the day's hello.  It shows how the surrounding durations
+// would change and, if the date/time looks out of place, asks the user to
+// confirm unless --force was entered.  Without a user to ask, e.g., with
+// --yes, it exits unless --force was entered.  It returns false if the user
+// declined.
+func validateTimeline(cmd *cobra.Command, db *database.Database, entry models.Entry) bool {
+	force, _ := cmd.Flags().GetBool(constants.FLAG_FORCE)
+
+	var anomalies []string
+	var entryTime carbon.Carbon = *carbon.Parse(entry.EntryDatetime)
+
+	// Warn about entries in the future.  A minute of slack allows for the time
+	// spent answering prompts.
+	if entryTime.Gt(carbon.Now().AddMinute()) {
+		anomalies = append(anomalies, fmt.Sprintf("%s is in the future", entryTime.ToIso8601String(carbon.Local)))
+	}
+
+	// Everything but a hello belongs after the day's hello.
+	if !strings.EqualFold(entry.Project, constants.HELLO) {
+		var hello models.Entry = models.NewEntry(constants.UNKNOWN_UID, constants.EMPTY, constants.EMPTY, constants.EMPTY)
+		for _, e := range db.GetEntriesForToday(*entryTime.Copy().StartOfDay(), *entryTime.Copy().EndOfDay()) {
+			if e.Uid != entry.Uid && strings.EqualFold(e.Project, constants.HELLO) {
+				hello = e
+				break
+			}
+		}
+
+		if hello.Uid == constants.UNKNOWN_UID {
+			anomalies = append(anomalies, fmt.Sprintf("there is no %s on %s", constants.HELLO, entryTime.ToDateString(carbon.Local)))
+		} else if !carbon.Parse(hello.EntryDatetime).Lt(&entryTime) {
+			anomalies = append(anomalies, fmt.Sprintf("%s is not after the day's %s at %s", entryTime.ToIso8601String(carbon.Local),
+				constants.HELLO, carbon.Parse(hello.EntryDatetime).ToIso8601String(carbon.Local)))
+		}
+	}
+
+	// Two entries at the same date/time make a zero duration.
+	for _, e := range db.GetEntriesForToday(entryTime, entryTime) {
+		if e.Uid != entry.Uid {
+			anomalies = append(anomalies, fmt.Sprintf("%s is the same date/time as the %s entry", entryTime.ToIso8601String(carbon.Local), neighbourName(e)))
+			break
+		}
+	}
+
+	// Work out the window of the timeline affected by the change.  For an
+	// amended entry, that includes where it was as well as where it is going.
+	var previous models.Entry = entryBefore(db, entry.EntryDatetime, entry.Uid)
+	var next models.Entry = entryAfter(db, entry.EntryDatetime, entry.Uid)
+	var from string = previous.EntryDatetime
+	var to string = next.EntryDatetime
+
+	if entry.Uid != constants.UNKNOWN_UID {
+		var original models.Entry = db.GetEntry(entry.Uid)
+		var originalPrevious models.Entry = entryBefore(db, original.EntryDatetime, entry.Uid)
+		var originalNext models.Entry = entryAfter(db, original.EntryDatetime, entry.Uid)
+
+		if originalPrevious.Uid != previous.Uid || originalNext.Uid != next.Uid {
+			anomalies = append(anomalies, "the entry moves past other entries, changing the order of the timeline")
+		}
+
+		from = earliest(from, originalPrevious.EntryDatetime, original.EntryDatetime)
+		to = latest(to, originalNext.EntryDatetime, original.EntryDatetime)
+	}
+
+	from = earliest(from, entry.EntryDatetime)
+	to = latest(to, entry.EntryDatetime)
+
+	showDurationChanges(db, entry, from, to)
+
+	if len(anomalies) == 0 {
+		return true
+	}
+
+	for _, anomaly := range anomalies {
+		log.Printf("%s: %s.\n", color.YellowString("Warning"), anomaly)
+	}
+
+	if force {
+		return true
+	}
+
+	// --yes answers the confirmations, but an out of place date/time is only
+	// ever accepted by the user or --force.
+	if assumeYes || !isInteractive() {
+		log.Printf("%s: This date/time looks out of place; use --%s to accept it anyway.\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.FLAG_FORCE)
+		os.Exit(constants.EXIT_CONFIRMATION_REQUIRED)
+	}
+
+	return yesNoPrompt("\nThis date/time looks out of place. Continue anyway?")
+}
+
+// showDurationChanges renders the durations of the entries between from and
+// to, before and after the entry is written, when any existing entry's
+// duration would change.
+func showDurationChanges(db *database.Database, entry models.Entry, from string, to string) {
+	var before []models.Entry = db.GetEntriesForToday(*carbon.Parse(from), *carbon.Parse(to))
+
+	// Build the timeline as it would be after the change.
+	var after []models.Entry
+	for _, e := range before {
+		if e.Uid != entry.Uid {
+			after = append(after, e)
+		}
+	}
+	after = append(after, entry)
+	sort.SliceStable(after, func(i, j int) bool {
+		return carbon.Parse(after[i].EntryDatetime).Lt(carbon.Parse(after[j].EntryDatetime))
+	})
+
+	var beforeDurations map[int64]int64 = timelineDurations(before)
+	var afterDurations map[int64]int64 = timelineDurations(after)
+
+	var changed bool = false
+	for uid, duration := range afterDurations {
+		if uid == entry.Uid {
+			continue
+		}
+
+		if oldDuration, found := beforeDurations[uid]; found && oldDuration != duration {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	var t table.Writer = table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.AppendHeader(table.Row{constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE, constants.DATE_TIME_NORMAL_CASE, "Old " + constants.DURATION_NORMAL_CASE, "New " + constants.DURATION_NORMAL_CASE})
+	for _, e := range after {
+		duration, found := afterDurations[e.Uid]
+		if !found {
+			continue
+		}
+
+		var oldDuration string = "-"
+		if d, found := beforeDurations[e.Uid]; found && e.Uid != constants.UNKNOWN_UID {
+			oldDuration = formatSeconds(d)
+		}
+
+		t.AppendRow(table.Row{e.Project, e.GetTasksAsString(), carbon.Parse(e.EntryDatetime).ToIso8601String(carbon.Local),
+			oldDuration, formatSeconds(duration)})
+	}
+
+	log.Printf("The durations around this entry change as follows\n\n%s\n\n", t.Render())
+}
+
+// timelineDurations returns the duration, in seconds, of each entry keyed by
+// uid.  The first entry has no known duration since its predecessor is not in
+// the list.
+func timelineDurations(entries []models.Entry) map[int64]int64 {
+	var result map[int64]int64 = make(map[int64]int64)
+
+	for i := 1; i < len(entries); i++ {
+		var entryTime carbon.Carbon = *carbon.Parse(entries[i].EntryDatetime)
+		result[entries[i].Uid] = carbon.Parse(entries[i-1].EntryDatetime).DiffInSeconds(&entryTime)
+	}
+
+	return result
+}
+
+func formatSeconds(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+// entryBefore returns the entry just before the given date/time, skipping the
+// entry with the given uid.
+func entryBefore(db *database.Database, entryDatetime string, skipUid int64) models.Entry {
+	var e models.Entry = db.GetEntryBefore(entryDatetime)
+	if e.Uid != constants.UNKNOWN_UID && e.Uid == skipUid {
+		e = db.GetEntryBefore(e.EntryDatetime)
+	}
+
+	return e
+}
+
+// entryAfter returns the entry just after the given date/time, skipping the
+// entry with the given uid.
+func entryAfter(db *database.Database, entryDatetime string, skipUid int64) models.Entry {
+	var e models.Entry = db.GetEntryAfter(entryDatetime)
+	if e.Uid != constants.UNKNOWN_UID && e.Uid == skipUid {
+		e = db.GetEntryAfter(e.EntryDatetime)
+	}
+
+	return e
+}
+
+// earliest returns the earliest of the given ISO8601 date/times, ignoring any
+// that are empty.
+func earliest(datetimes ...string) string {
+	var result string = constants.EMPTY
+	for _, d := range datetimes {
+		if d != constants.EMPTY && (result == constants.EMPTY || carbon.Parse(d).Lt(carbon.Parse(result))) {
+			result = d
+		}
+	}
+
+	return result
+}
+
+// latest returns the latest of the given ISO8601 date/times, ignoring any
+// that are empty.
+func latest(datetimes ...string) string {
+	var result string = constants.EMPTY
+	for _, d := range datetimes {
+		if d != constants.EMPTY && (result == constants.EMPTY || carbon.Parse(d).Gt(carbon.Parse(result))) {
+			result = d
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,770 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"khronos/constants"
+	"khronos/internal/database"
+	"khronos/internal/models"
+
+	"github.com/agrison/go-commons-lang/stringUtils"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dromara/carbon/v2"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// tuiCmd represents the tui command
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: constants.TUI_SHORT_DESCRIPTION,
+	Long:  constants.TUI_LONG_DESCRIPTION,
+	Run: func(cmd *cobra.Command, args []string) {
+		runTui(cmd, args)
+	},
+}
+
+func init() {
+	tuiCmd.Flags().StringP(constants.FLAG_DATE, constants.EMPTY, constants.EMPTY, "Open the dashboard on the given day in "+constants.DATE_FORMAT_YYYY_MM_DD+" format.")
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTui(cmd *cobra.Command, _ []string) {
+	requireInteractive("the dashboard needs a terminal", constants.EXIT_INPUT_REQUIRED)
+
+	var day carbon.Carbon = *carbon.Now()
+	givenDate, _ := cmd.Flags().GetString(constants.FLAG_DATE)
+	if !stringUtils.IsEmpty(givenDate) {
+		day = *carbon.Parse(givenDate)
+		if day.Error != nil {
+			log.Fatalf("%s: Unable to parse the date '%s'. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), givenDate, day.Error)
+			os.Exit(1)
+		}
+	}
+
+	db := database.New(viper.GetString(constants.DATABASE_FILE))
+	defer db.Close()
+
+	p := tea.NewProgram(newDashboardModel(db, day), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		log.Fatalf("%s: Error running the dashboard. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+}
+
+// dashboardModel is the full-screen dashboard.  It shows the day's timeline as
+// a bar of coloured blocks, one colour per project, the day's entries, and a
+// side panel summarising the day by project along with the week and month
+// totals.
+//
+// The actions run Khronos itself (see dashboardCommand) so adding, amending,
+// and splitting behave exactly like the commands, prompts included.  Deleting
+// is confirmed in the dashboard and goes through the same ReplaceEntries call
+// that `bulk --delete` uses; like split and merge, it refuses entries that
+// were already pushed.
+type dashboardModel struct {
+	db *database.Database
+
+	day     carbon.Carbon // the day shown, at the start of the day
+	now     carbon.Carbon
+	entries []models.Entry
+	summary daySummary
+
+	// The work time of each day of the week shown, and of its month.
+	weekStart carbon.Carbon
+	week      [7]int64
+	month     int64
+
+	cursor int // index into entries
+	width  int
+	height int
+
+	mode   dashboardMode
+	input  string // the split point being typed
+	status string // result of the last action, or an error
+}
+
+type dashboardMode int
+
+const (
+	dashboardModeBrowse dashboardMode = iota
+	dashboardModeConfirmDelete
+	dashboardModeSplitPoint
+)
+
+// daySummary holds a day's durations, in seconds.  durations is keyed by entry
+// uid; the day's first entry, normally the hello, has none.
+type daySummary struct {
+	durations map[int64]int64
+	projects  map[string]int64
+	work      int64
+	breaks    int64
+	untracked int64
+}
+
+// dashboardTickMsg refreshes the dashboard so the time since the last entry
+// keeps running, and entries added from another terminal show up.
+type dashboardTickMsg time.Time
+
+// dashboardActionMsg is sent when a command run from the dashboard finishes.
+type dashboardActionMsg struct {
+	action string
+	today  bool // show today afterwards, since that is where the entry went
+	err    error
+}
+
+// The colours of the projects in the timeline.  Breaks, untracked time, and
+// the time since the last entry have their own.
+var dashboardColors = []lipgloss.Color{"4", "2", "3", "5", "6", "12", "10", "11", "13", "14", "9", "1"}
+
+const (
+	dashboardBreakColor     = lipgloss.Color("8")
+	dashboardUntrackedColor = lipgloss.Color("1")
+	dashboardUnloggedColor  = lipgloss.Color("241")
+	dashboardPanelWidth     = 34
+)
+
+func newDashboardModel(db *database.Database, day carbon.Carbon) dashboardModel {
+	var m dashboardModel = dashboardModel{
+		db:     db,
+		day:    *day.Copy().StartOfDay(),
+		width:  terminalWidth,
+		height: 24,
+	}
+
+	m = m.load()
+
+	// Start on the latest entry, since that is the one most likely to change.
+	m.cursor = max(0, len(m.entries)-1)
+	return m
+}
+
+// load re-reads the day's entries, and the week's and month's totals, from the
+// database.
+func (m dashboardModel) load() dashboardModel {
+	m.now = *carbon.Now()
+	m.entries = m.db.GetEntriesForToday(*m.day.Copy().StartOfDay(), *m.day.Copy().EndOfDay())
+	m.summary = summarizeDay(m.entries)
+
+	var start carbon.Carbon = *m.day.Copy()
+	var end carbon.Carbon
+	dateRange(&start, &end)
+	m.weekStart = start
+
+	var week map[string]int64 = workByDay(m.db.GetEntriesForToday(start, end))
+	for i := range m.week {
+		m.week[i] = week[start.Copy().AddDays(i).ToDateString()]
+	}
+
+	m.month = 0
+	for _, work := range workByDay(m.db.GetEntriesForToday(*m.day.Copy().StartOfMonth(), *m.day.Copy().EndOfMonth())) {
+		m.month += work
+	}
+
+	m.cursor = max(0, min(m.cursor, len(m.entries)-1))
+	return m
+}
+
+// summarizeDay works out the durations of a day's entries.  Like the report,
+// the hello and untracked time are neither work nor break.
+func summarizeDay(entries []models.Entry) daySummary {
+	var s daySummary = daySummary{
+		durations: timelineDurations(entries),
+		projects:  make(map[string]int64),
+	}
+
+	for _, e := range entries {
+		duration, found := s.durations[e.Uid]
+		if !found {
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(e.Project, constants.HELLO):
+		case strings.EqualFold(e.Project, constants.BREAK):
+			s.breaks += duration
+		case strings.EqualFold(e.Project, constants.UNTRACKED):
+			s.untracked += duration
+		default:
+			s.work += duration
+			s.projects[e.Project] += duration
+		}
+	}
+
+	return s
+}
+
+// workByDay returns the work time of each day, keyed by date, of the given
+// entries.
+func workByDay(entries []models.Entry) map[string]int64 {
+	var days map[string][]models.Entry = make(map[string][]models.Entry)
+	for _, e := range entries {
+		var date string = carbon.Parse(e.EntryDatetime).ToDateString()
+		days[date] = append(days[date], e)
+	}
+
+	var result map[string]int64 = make(map[string]int64)
+	for date, dayEntries := range days {
+		result[date] = summarizeDay(dayEntries).work
+	}
+
+	return result
+}
+
+// isToday reports whether the dashboard is showing today.
+func (m dashboardModel) isToday() bool {
+	return m.day.IsSameDay(m.now.Copy().StartOfDay())
+}
+
+// sinceLastEntry returns the seconds since today's last entry, or 0 if the
+// dashboard is not showing today.
+func (m dashboardModel) sinceLastEntry() int64 {
+	if !m.isToday() || len(m.entries) == 0 {
+		return 0
+	}
+
+	return max(0, m.now.Timestamp()-carbon.Parse(m.entries[len(m.entries)-1].EntryDatetime).Timestamp())
+}
+
+// showDay moves the dashboard to the given day.
+func (m dashboardModel) showDay(day carbon.Carbon) dashboardModel {
+	m.day = *day.Copy().StartOfDay()
+	m.cursor = 0
+	m.status = ""
+	m = m.load()
+	m.cursor = max(0, len(m.entries)-1)
+	return m
+}
+
+func dashboardTick() tea.Cmd {
+	return tea.Tick(time.Minute, func(t time.Time) tea.Msg {
+		return dashboardTickMsg(t)
+	})
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return dashboardTick()
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case dashboardTickMsg:
+		return m.load(), dashboardTick()
+
+	case dashboardActionMsg:
+		if msg.today {
+			m = m.showDay(*carbon.Now())
+		} else {
+			m = m.load()
+		}
+
+		m.status = msg.action + " finished."
+		var exitErr *exec.ExitError
+		if errors.As(msg.err, &exitErr) {
+			m.status = fmt.Sprintf("%s exited with status %d.", msg.action, exitErr.ExitCode())
+		} else if msg.err != nil {
+			m.status = "Error: " + msg.err.Error()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.mode {
+		case dashboardModeConfirmDelete:
+			return m.updateConfirmDelete(msg)
+		case dashboardModeSplitPoint:
+			return m.updateSplitPoint(msg)
+		}
+
+		return m.updateBrowse(msg)
+	}
+
+	return m, nil
+}
+
+func (m dashboardModel) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+		}
+
+	case "left", "h":
+		return m.showDay(*m.day.Copy().AddDays(-1)), nil
+
+	case "right", "l":
+		return m.showDay(*m.day.Copy().AddDays(1)), nil
+
+	case "[":
+		return m.showDay(*m.day.Copy().AddWeeks(-1)), nil
+
+	case "]":
+		return m.showDay(*m.day.Copy().AddWeeks(1)), nil
+
+	case "{":
+		return m.showDay(*m.day.Copy().AddMonthsNoOverflow(-1)), nil
+
+	case "}":
+		return m.showDay(*m.day.Copy().AddMonthsNoOverflow(1)), nil
+
+	case "t":
+		return m.showDay(*carbon.Now()), nil
+
+	case "r":
+		m = m.load()
+		m.status = ""
+
+	case "a":
+		return m, m.run("add", true, "add")
+
+	case "b":
+		return m, m.run("break", true, "break")
+
+	case "s":
+		return m, m.run("stretch", true, "stretch")
+
+	case "e", "enter":
+		if entry, ok := m.current(); ok {
+			return m, m.run("amend", false, "amend", "--"+constants.FLAG_UID, strconv.FormatInt(entry.Uid, 10))
+		}
+
+	case "x":
+		if entry, ok := m.current(); ok {
+			if strings.EqualFold(entry.Project, constants.HELLO) {
+				m.status = "A " + constants.HELLO + " entry cannot be split."
+				return m, nil
+			}
+
+			m.mode = dashboardModeSplitPoint
+			m.input = ""
+			m.status = ""
+		}
+
+	case "d":
+		if entry, ok := m.current(); ok {
+			if strings.EqualFold(entry.Project, constants.HELLO) {
+				m.status = "A " + constants.HELLO + " entry cannot be deleted here."
+				return m, nil
+			}
+
+			if entry.IsPushed() {
+				m.status = "The entry was already pushed and cannot be deleted here."
+				return m, nil
+			}
+
+			m.mode = dashboardModeConfirmDelete
+			m.status = ""
+		}
+	}
+
+	return m, nil
+}
+
+func (m dashboardModel) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = dashboardModeBrowse
+
+	switch msg.String() {
+	case "y", "Y":
+		entry, _ := m.current()
+		m.db.ReplaceEntries([]int64{entry.Uid}, []models.Entry{})
+		m = m.load()
+		m.status = "Entry deleted."
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	default:
+		m.status = "Nothing deleted."
+	}
+
+	return m, nil
+}
+
+func (m dashboardModel) updateSplitPoint(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.mode = dashboardModeBrowse
+		m.status = "Nothing split."
+		return m, nil
+
+	case "enter":
+		m.mode = dashboardModeBrowse
+		if strings.TrimSpace(m.input) == "" {
+			m.status = "Nothing split."
+			return m, nil
+		}
+
+		entry, _ := m.current()
+		return m, m.run("split", false, "split", "--"+constants.FLAG_UID, strconv.FormatInt(entry.Uid, 10),
+			"--"+constants.FLAG_POINT, strings.TrimSpace(m.input))
+	}
+
+	m.input, _ = editFilterQuery(m.input, msg)
+	return m, nil
+}
+
+// current returns the entry under the cursor.
+func (m dashboardModel) current() (models.Entry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return models.Entry{}, false
+	}
+
+	return m.entries[m.cursor], true
+}
+
+// run suspends the dashboard and runs Khronos with the given arguments.
+func (m dashboardModel) run(action string, today bool, args ...string) tea.Cmd {
+	return tea.Exec(&dashboardCommand{args: args}, func(err error) tea.Msg {
+		return dashboardActionMsg{action: action, today: today, err: err}
+	})
+}
+
+// dashboardCommand runs Khronos itself with the given arguments in the
+// terminal the dashboard was using, then waits for enter so the command's
+// output can be read before the dashboard comes back.
+type dashboardCommand struct {
+	args   []string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (c *dashboardCommand) SetStdin(r io.Reader)  { c.stdin = r }
+func (c *dashboardCommand) SetStdout(w io.Writer) { c.stdout = w }
+func (c *dashboardCommand) SetStderr(w io.Writer) { c.stderr = w }
+
+func (c *dashboardCommand) Run() error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// The command must use the same configuration as the dashboard.
+	var args []string = c.args
+	if cfgFile != constants.EMPTY {
+		args = append([]string{"--config", cfgFile}, args...)
+	}
+
+	var cmd *exec.Cmd = exec.Command(executable, args...)
+	cmd.Stdin = c.stdin
+	cmd.Stdout = c.stdout
+	cmd.Stderr = c.stderr
+	err = cmd.Run()
+
+	fmt.Fprint(c.stdout, "\nPress enter to return to the dashboard...")
+	_, _ = bufio.NewReader(c.stdin).ReadString('\n')
+
+	return err
+}
+
+func (m dashboardModel) View() string {
+	var title lipgloss.Style = lipgloss.NewStyle().Bold(true)
+	var dim lipgloss.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var heading string = m.day.Layout("Monday, January 2 2006")
+	if m.isToday() {
+		heading += " (today)"
+	}
+
+	var b strings.Builder
+	b.WriteString(title.Render(constants.APPLICATION_NAME+" - "+heading) + "\n")
+	b.WriteString(m.renderWeek() + "\n\n")
+	b.WriteString(m.renderTimeline())
+	b.WriteString("\n")
+
+	var listWidth int = max(40, m.width-dashboardPanelWidth-2)
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, m.renderEntries(listWidth), "  ", m.renderPanel()))
+	b.WriteString("\n")
+
+	if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
+
+	switch m.mode {
+	case dashboardModeConfirmDelete:
+		entry, _ := m.current()
+		b.WriteString(fmt.Sprintf("Delete the %s entry at %s? y/n", neighbourName(entry),
+			carbon.Parse(entry.EntryDatetime).ToTimeString(carbon.Local)))
+
+	case dashboardModeSplitPoint:
+		b.WriteString("Split at (natural language time, enter: split, esc: cancel): " + m.input)
+
+	default:
+		b.WriteString(dim.Render("up/down: entry - left/right: day - [/]: week - {/}: month - t: today - a: add - b: break - " +
+			"s: stretch - e: amend - x: split - d: delete - r: reload - q: quit"))
+	}
+
+	return b.String()
+}
+
+// renderWeek renders the work time of each day of the week, highlighting the
+// day shown.
+func (m dashboardModel) renderWeek() string {
+	var days []string
+	for i, work := range m.week {
+		var day carbon.Carbon = *m.weekStart.Copy().AddDays(i)
+		var cell string = " " + day.Layout("Mon 02") + " " + formatHoursMinutes(work) + " "
+
+		var style lipgloss.Style = lipgloss.NewStyle()
+		if day.IsSameDay(&m.day) {
+			style = style.Reverse(true)
+		}
+		if day.IsSameDay(m.now.Copy().StartOfDay()) {
+			style = style.Underline(true)
+		}
+
+		days = append(days, style.Render(cell))
+	}
+
+	return strings.Join(days, "|")
+}
+
+// renderTimeline renders the day as a bar, from the first entry to the last
+// one, or to now for today.  Each entry's block runs from the previous entry
+// to the entry, in its project's colour.  Below the bar, the entry under the
+// cursor is marked, followed by the hours.
+func (m dashboardModel) renderTimeline() string {
+	var width int = max(20, m.width)
+
+	var times []int64
+	for _, e := range m.entries {
+		times = append(times, carbon.Parse(e.EntryDatetime).Timestamp())
+	}
+
+	if len(times) == 0 {
+		return "No entries.\n"
+	}
+
+	var start int64 = times[0]
+	var end int64 = times[len(times)-1]
+	if m.isToday() && m.now.Timestamp() > end {
+		end = m.now.Timestamp()
+	}
+
+	if end <= start {
+		return "Nothing logged since " + carbon.Parse(m.entries[0].EntryDatetime).ToTimeString(carbon.Local) + ".\n"
+	}
+
+	var bar strings.Builder
+	var marker []rune = []rune(strings.Repeat(" ", width))
+	var marked bool = false
+	for c := 0; c < width; c++ {
+		// Colour the column by the entry covering the middle of it.
+		var t int64 = start + (2*int64(c)+1)*(end-start)/(2*int64(width))
+		var index int = sort.Search(len(times), func(i int) bool {
+			return times[i] >= t
+		})
+
+		if index == len(times) {
+			bar.WriteString(lipgloss.NewStyle().Foreground(dashboardUnloggedColor).Render("·"))
+			continue
+		}
+
+		bar.WriteString(dashboardBlock(m.entries[index].Project))
+		if index == m.cursor {
+			marker[c] = '^'
+			marked = true
+		}
+	}
+
+	// An entry shorter than a column, or the hello, is marked where it ends.
+	if !marked && m.cursor < len(times) {
+		marker[min(width-1, int((times[m.cursor]-start)*int64(width)/(end-start)))] = '^'
+	}
+
+	// Label the hours, skipping any that would overlap the previous label.
+	var axis []rune = []rune(strings.Repeat(" ", width))
+	var next int = 0
+	var hour carbon.Carbon = *carbon.CreateFromTimestamp(start).SetTimezone(carbon.Local).StartOfHour().AddHour()
+	for ; hour.Timestamp() < end; hour = *hour.AddHour() {
+		var c int = int((hour.Timestamp() - start) * int64(width) / (end - start))
+		var label string = hour.Layout("15")
+		if c < next || c+len(label) > width {
+			continue
+		}
+
+		copy(axis[c:], []rune(label))
+		next = c + len(label) + 1
+	}
+
+	return bar.String() + "\n" + string(marker) + "\n" + string(axis) + "\n"
+}
+
+// dashboardBlock renders one column of the timeline for an entry of the given
+// project.
+func dashboardBlock(project string) string {
+	switch {
+	case strings.EqualFold(project, constants.BREAK):
+		return lipgloss.NewStyle().Foreground(dashboardBreakColor).Render("▒")
+	case strings.EqualFold(project, constants.UNTRACKED):
+		return lipgloss.NewStyle().Foreground(dashboardUntrackedColor).Render("░")
+	}
+
+	return lipgloss.NewStyle().Foreground(projectColor(project)).Render("█")
+}
+
+// projectColor returns the colour of a project, which stays the same from day
+// to day.
+func projectColor(project string) lipgloss.Color {
+	var h = fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(project)))
+	return dashboardColors[h.Sum32()%uint32(len(dashboardColors))]
+}
+
+// renderEntries renders the day's entries, scrolled so the cursor stays in
+// view.
+func (m dashboardModel) renderEntries(width int) string {
+	if len(m.entries) == 0 {
+		return ""
+	}
+
+	// Leave room for the heading, timeline, table borders, and help.
+	var rows int = max(3, m.height-16)
+	var first int = max(0, min(m.cursor-rows/2, len(m.entries)-rows))
+	var last int = min(len(m.entries), first+rows)
+
+	var t table.Writer = table.NewWriter()
+	t.SetAllowedRowLength(width)
+	t.AppendHeader(table.Row{"", "Time", constants.DURATION_NORMAL_CASE, constants.PROJECT_NORMAL_CASE, constants.TASK_NORMAL_CASE,
+		constants.TICKET_NORMAL_CASE, constants.NOTE_NORMAL_CASE})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 7, WidthMax: max(10, width-70), WidthMaxEnforcer: text.Trim},
+	})
+
+	for i := first; i < last; i++ {
+		var e models.Entry = m.entries[i]
+
+		// Only the entries with a block in the timeline get a swatch.
+		var swatch, duration string
+		if d, found := m.summary.durations[e.Uid]; found && !strings.EqualFold(e.Project, constants.HELLO) {
+			swatch = dashboardBlock(e.Project) + dashboardBlock(e.Project)
+			duration = formatHoursMinutes(d)
+		}
+
+		t.AppendRow(table.Row{swatch, carbon.Parse(e.EntryDatetime).ToTimeString(carbon.Local),
+			duration, e.Project, e.GetTasksAsString(), e.GetTicketAsString(), e.Note})
+	}
+
+	cursor := m.cursor - first
+	t.SetRowPainter(table.RowPainterWithAttributes(func(row table.Row, attr table.RowAttributes) text.Colors {
+		if attr.Number-1 == cursor {
+			return text.Colors{text.BgBlue, text.FgHiWhite}
+		}
+		return nil
+	}))
+
+	return t.Render()
+}
+
+// renderPanel renders the day's by-project summary and the running totals.
+func (m dashboardModel) renderPanel() string {
+	var bold lipgloss.Style = lipgloss.NewStyle().Bold(true)
+	var inner int = dashboardPanelWidth - 4
+
+	line := func(label string, seconds int64) string {
+		var value string = formatHoursMinutes(seconds)
+		return label + strings.Repeat(" ", max(1, inner-lipgloss.Width(label)-len(value))) + value
+	}
+
+	var projects []string
+	for project := range m.summary.projects {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return m.summary.projects[projects[i]] > m.summary.projects[projects[j]]
+	})
+
+	var lines []string = []string{bold.Render("By project")}
+	for _, project := range projects {
+		lines = append(lines, line(dashboardBlock(project)+" "+truncateWithEllipsis(project, inner-10), m.summary.projects[project]))
+	}
+	if len(projects) == 0 {
+		lines = append(lines, "Nothing logged.")
+	}
+
+	lines = append(lines, "", line("Work", m.summary.work), line("Break", m.summary.breaks))
+	if m.summary.untracked > 0 {
+		lines = append(lines, line("Untracked", m.summary.untracked))
+	}
+	if m.isToday() {
+		lines = append(lines, line("Since last entry", m.sinceLastEntry()))
+	}
+	lines = append(lines, "", line("Week", sumWeek(m.week)), line("Month", m.month))
+
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).Width(dashboardPanelWidth - 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+func sumWeek(week [7]int64) int64 {
+	var total int64 = 0
+	for _, work := range week {
+		total += work
+	}
+
+	return total
+}
+
+// formatHoursMinutes formats seconds as hours and minutes, e.g., 7h05m.
+func formatHoursMinutes(seconds int64) string {
+	return fmt.Sprintf("%dh%02dm", seconds/3600, seconds%3600/60)
+}
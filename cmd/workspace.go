@@ -0,0 +1,232 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"khronos/constants"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+)
+
+// Workspace is a rule mapping the directories matching a glob, or the git
+// repositories whose remote matches one, to the project worked on in them.
+// When both are given, both must match.
+type Workspace struct {
+	Directory     string   `yaml:"directory" mapstructure:"directory"`
+	Remote        string   `yaml:"remote" mapstructure:"remote"`
+	Project       string   `yaml:"project" mapstructure:"project"`
+	Task          string   `yaml:"task" mapstructure:"task"`
+	TicketPattern string   `yaml:"ticket_pattern" mapstructure:"ticket_pattern"`
+	Favorites     []string `yaml:"favorites" mapstructure:"favorites"`
+}
+
+// workspaceKeys holds the settings a workspace may have.
+var workspaceKeys = map[string]bool{
+	"directory": true, "remote": true, "project": true, "task": true, "ticket_pattern": true, "favorites": true,
+}
+
+// workspaceMatch is the workspace rule matching the current directory.
+type workspaceMatch struct {
+	Workspace
+	Number int    // 1-based position of the rule in the configuration
+	Reason string // what matched, e.g., directory ~/src/acme-*
+}
+
+// loadWorkspaces returns the workspace rules, in the order they are tried: the
+// global ones, followed by those of any per-directory configuration files.
+func loadWorkspaces() []Workspace {
+	var workspaces []Workspace
+	err := viper.UnmarshalKey(constants.WORKSPACES, &workspaces)
+	if err != nil {
+		log.Fatalf("%s: Error reading the %s from the configuration. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), constants.WORKSPACES, err.Error())
+		os.Exit(1)
+	}
+
+	for _, layer := range configLayers {
+		workspaces = append(workspaces, layer.workspaces...)
+	}
+
+	return workspaces
+}
+
+// currentWorkspace returns the first workspace rule matching the current
+// directory, if any.
+func currentWorkspace() (workspaceMatch, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return workspaceMatch{}, false
+	}
+
+	return matchWorkspace(loadWorkspaces(), dir)
+}
+
+// matchWorkspace returns the first workspace rule matching dir, or the git
+// repository it is in.
+func matchWorkspace(workspaces []Workspace, dir string) (workspaceMatch, bool) {
+	var remotes []string
+	if gitDir, found := findGitDir(dir); found {
+		for _, url := range gitRemoteURLs(gitDir) {
+			remotes = append(remotes, normalizeRemoteURL(url))
+		}
+	}
+
+	for i, w := range workspaces {
+		var reasons []string
+		if w.Directory != constants.EMPTY {
+			if !matchDirectory(w.Directory, dir) {
+				continue
+			}
+			reasons = append(reasons, "directory "+w.Directory)
+		}
+
+		if w.Remote != constants.EMPTY {
+			remote, found := matchRemote(w.Remote, remotes)
+			if !found {
+				continue
+			}
+			reasons = append(reasons, "remote "+remote)
+		}
+
+		if len(reasons) > 0 {
+			return workspaceMatch{Workspace: w, Number: i + 1, Reason: strings.Join(reasons, " and ")}, true
+		}
+	}
+
+	return workspaceMatch{}, false
+}
+
+// matchDirectory reports whether dir, or one of its parents, matches the
+// glob, so a rule for a repository also applies in its subdirectories.  A
+// leading ~ is the home directory.
+func matchDirectory(pattern string, dir string) bool {
+	pattern = filepath.Clean(expandHome(pattern))
+	for {
+		if matched, _ := filepath.Match(pattern, dir); matched {
+			return true
+		}
+
+		var parent string = filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// matchRemote returns the first of the normalized remote URLs matching the
+// glob, which is normalized the same way.
+func matchRemote(pattern string, remotes []string) (string, bool) {
+	pattern = normalizeRemoteURL(pattern)
+	for _, remote := range remotes {
+		if matched, _ := path.Match(pattern, remote); matched {
+			return remote, true
+		}
+	}
+
+	return constants.EMPTY, false
+}
+
+// expandHome replaces a leading ~ with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, path[1:])
+}
+
+// ProjectTask returns the workspace's default project+task, or empty if it
+// has no default task.
+func (w Workspace) ProjectTask() string {
+	if w.Project == constants.EMPTY || w.Task == constants.EMPTY {
+		return constants.EMPTY
+	}
+
+	return w.Project + constants.TASK_DELIMITER + w.Task
+}
+
+// inScope reports whether the favorite belongs to the workspace: it matches
+// one of the workspace's favorites globs, by project+task or alias, or, if
+// there are none, is for the workspace's project.
+func (w Workspace) inScope(f Favorite) bool {
+	if len(w.Favorites) == 0 {
+		project, _, _ := strings.Cut(f.Favorite, constants.TASK_DELIMITER)
+		return w.Project != constants.EMPTY && strings.EqualFold(project, w.Project)
+	}
+
+	for _, pattern := range w.Favorites {
+		if globMatch(pattern, f.Favorite) || (f.Alias != constants.EMPTY && globMatch(pattern, f.Alias)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ticketRegex returns the workspace's ticket pattern, which must match the
+// whole ticket, or nil if it has none.
+func (w Workspace) ticketRegex() (*regexp.Regexp, error) {
+	if w.TicketPattern == constants.EMPTY {
+		return nil, nil
+	}
+
+	_, err := regexp.Compile(w.TicketPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket_pattern[%s]. %s", w.TicketPattern, err.Error())
+	}
+
+	return regexp.MustCompile("^(?:" + w.TicketPattern + ")$"), nil
+}
+
+// globMatch reports whether s matches the glob, without regard to case.  A *
+// matches any run of characters, including /, and a ? any one character.
+func globMatch(pattern string, s string) bool {
+	var expression string = regexp.QuoteMeta(pattern)
+	expression = strings.ReplaceAll(expression, `\*`, ".*")
+	expression = strings.ReplaceAll(expression, `\?`, ".")
+
+	matched, _ := regexp.MatchString("(?i)^"+expression+"$", s)
+	return matched
+}
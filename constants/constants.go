@@ -40,7 +40,9 @@ const AMEND_SHORT_DESCRIPTION = "Amend an entry"
 const AMENDING string = "Amending"
 const APPLICATION_NAME = "Khronos"
 const APPLICATION_NAME_LOWERCASE = "khronos"
+const ALIAS string = "alias"
 const AT string = "at"
+const AT_PREFIX string = "@"
 const BACKEND_LONG_DESCRIPTION = "Open a sqlite shell to the database. REQUIRES sqlite standalone application in user path."
 const BACKEND_SHORT_DESCRIPTION = "Open a sqlite shell to the database"
 const BACKUP_LONG_DESCRIPTION = "Before making major changes to your database, make a backup."
@@ -48,14 +50,19 @@ const BACKUP_SHORT_DESCRIPTION = "Backup your database"
 const BREAK string = "***break"
 const BREAK_LONG_DESCRIPTION = "If you just spent time on break, use this command to add that time to the database."
 const BREAK_SHORT_DESCRIPTION = "Add a break"
+const BULK_LONG_DESCRIPTION = "Change the project, task, ticket, and/or note of several entries at once, or delete them, default is today's entries. The entries are chosen in an interactive selector, or with --all."
+const BULK_SHORT_DESCRIPTION = "Amend or delete several entries at once"
 const CARBON_DATE_FORMAT string = "Y-m-d"
 const CARBON_START_END_TIME_FORMAT string = "h:ia"
 const CARBON_START_END_TIME_24H_FORMAT string = "H:i"
+const COMMIT_HOOK string = "commit_hook"
+const COMMIT_HOOK_ENTRY string = "entry"
+const COMMIT_HOOK_SUGGEST string = "suggest"
 const COMPRESS string = "compress"
 const COMPRESS_DESCRIPTION string = "Compress archive file in gzip format"
 const CONFIGURATION_FILE string = ".khronos.yaml"
-const CONFIGURE_LONG_DESCRIPTION = "Write out a YAML config file. Print path to config file."
-const CONFIGURE_SHORT_DESCRIPTION = "Write out a YAML config file"
+const CONFIG_LONG_DESCRIPTION = "Print the path to the configuration file, or list, get, set, and validate its settings.  The init subcommand walks through the main settings interactively."
+const CONFIG_SHORT_DESCRIPTION = "Show, change, and validate the configuration"
 const COMMAND_BACKUP = "backup"
 const COMMAND_BACKEND = "backend"
 const COMMAND_CONVERT = "convert"
@@ -74,35 +81,90 @@ const DESCRIPTION = "description"
 const DISPLAY_BY_DAY_TOTALS string = "display_by_day_totals"
 const DISPLAY_HMS_ABBREVIATED = "display_hms_abbreviated"
 const DISPLAY_TIME_IN_24H_FORMAT = "display_time_in_24h_format"
+const DOCTOR_LONG_DESCRIPTION = "Find the entries, default is every entry, whose notes mention a ticket they do not have, e.g., ABC-812, using the ticket_note_pattern and ticket_note_projects settings. Once confirmed, the tickets are added so the entries are pushed."
+const DOCTOR_SHORT_DESCRIPTION = "Add the tickets mentioned in the notes of existing entries"
 const DONE = "Done"
 const DRY_RUN = "dry-run"
 const DRY_RUN_DESCRIPTION = "Do not actually nuke anything, but show what potential would be nuked."
 const DURATION_NORMAL_CASE = "Duration"
-const EDIT_LONG_DESCRIPTION = "Open the Khronos configuration file in your default editor."
-const EDIT_SHORT_DESCRIPTION = "Open the Khronos configuration file in your default editor"
+const EDIT_LONG_DESCRIPTION = "Open the Khronos configuration file in your editor, from $VISUAL or $EDITOR, and validate it once the editor exits."
+const EDIT_SHORT_DESCRIPTION = "Open the Khronos configuration file in your editor"
 const EMPTY string = ""
+const ENTRY_NORMAL_CASE = "Entry"
+const EXIT_CONFIRMATION_REQUIRED = 3
+const EXIT_INPUT_REQUIRED = 4
+const EXIT_SELECTION_REQUIRED = 5
 const EXPORT = "export"
 const EXPORT_TYPE = "type"
 const FATAL_NORMAL_CASE string = "Fatal"
+const FLAG_ALIAS = "alias"
+const FLAG_AS = "as"
+const FLAG_AS_DESCRIPTION = "The project+task, optionally followed by ': note', of a segment. Specify once per segment, in order."
+const FLAG_AFTER = "after"
+const FLAG_ALL = "all"
+const FLAG_AUTHOR = "author"
+const FLAG_BY = "by"
+const FLAG_BY_PROJECT = "by-project"
 const FAVORITE string = "favorite"
+const FAVORITE_ORDER string = "favorite_order"
+const FAVORITE_ORDER_CONFIG string = "config"
+const FAVORITE_ORDER_SMART string = "smart"
+const FAVORITE_RECENT string = "favorite_recent"
+const FAVORITE_LONG_DESCRIPTION = "Add, remove, edit, move, and list the favorites in the Khronos configuration file. Comments and ordering in the file are preserved."
+const FAVORITE_SHORT_DESCRIPTION = "Manage your favorites"
 const FAVORITES string = "favorites"
 const FLAG_CURRENT_WEEK = "current-week"
 const FLAG_DATE = "date"
+const FLAG_DAYS = "days"
+const FLAG_DELETE = "delete"
+const FLAG_DESCRIPTION = "description"
+const FLAG_DURATION = "duration"
+const FLAG_FOR = "for"
+const FLAG_FORCE = "force"
+const FLAG_FORCE_DESCRIPTION = "Do not ask for confirmation when the date/time is out of place, e.g., in the future or before the day's hello."
+const FLAG_FOR_DESCRIPTION = "Duration of the entry, e.g., '45m' or '1h30m'. The entry starts this long before its end time."
 const FLAG_FROM = "from"
+const FLAG_FROM_FILE = "from-file"
+const FLAG_FROM_FILE_DESCRIPTION = "Add every entry in the given time log file, or '-' for stdin. Each line is '[YYYY-MM-DD] HH:MM hello|break|project+task [note]'."
+const FLAG_GAP = "gap"
+const FLAG_GAP_DESCRIPTION = "How to fill a gap between the previous entry and the start of this entry, either 'break' or 'untracked'."
+const FLAG_INTERACTIVE = "interactive"
 const FLAG_LAST_ENTRY = "last-entry"
+const FLAG_LIMIT = "limit"
+const FLAG_MIN_USES = "min-uses"
 const FLAG_NO_ROUNDING = "no-rounding"
+const FLAG_POINT = "point"
+const FLAG_POINT_DESCRIPTION = "A point to split the entry at, either a Natural Language Time, e.g., '10:30am', or a duration from the previous point, e.g., '2h'. Specify once per point."
 const FLAG_PREVIOUS_WEEK = "previous-week"
 const FLAG_PROJECT = "project"
+const FLAG_PROPERTY = "property"
+const FLAG_REPOS = "repos"
+const FLAG_REQUIRE_NOTE = "require-note"
+const FLAG_TAG = "tag"
+const FLAG_TICKET = "ticket"
+const FLAG_TICKET_FROM_BRANCH = "ticket-from-branch"
+const FLAG_TICKET_FROM_BRANCH_DESCRIPTION = "Add the ticket in the current git branch's name, e.g., feature/ABC-123-fix, to the entry."
 const FLAG_TO = "to"
 const FLAG_TODAY = "today"
+const FLAG_UID = "uid"
+const FLAG_NON_INTERACTIVE = "non-interactive"
+const FLAG_NON_INTERACTIVE_DESCRIPTION = "Never prompt for input; exit with a non-zero status if input is needed. Implied when stdin is not a terminal."
+const FLAG_YES = "yes"
+const FLAG_YES_DESCRIPTION = "Answer yes to every confirmation."
 const FLAG_PUSH = "push"
+const FLAG_SINCE = "since"
+const FLAG_SINCE_DESCRIPTION = "Natural Language Time the entry started, e.g., '9:15am' or '45 minutes ago'."
 const FLAG_YESTERDAY = "yesterday"
 const HELLO string = "***hello"
 const HELLO_LONG_DESCRIPTION = "In order to have khronos start tracking time is to run this command. It informs khronos that you would like it to start tracking your time."
 const HELLO_SHORT_DESCRIPTION = "Start time tracking for the day"
 const HELP_SHORT_DESCRIPTION = "Show help for command"
+const HOOKS_LONG_DESCRIPTION = "Install or uninstall a git post-commit hook that records every commit in Khronos, either as a pending commit for the suggest command or, with commit_hook: entry, as an entry. The hook never blocks or fails a commit, and works alongside an existing post-commit hook."
+const HOOKS_SHORT_DESCRIPTION = "Record your git commits with a post-commit hook"
 const INDENT_AMOUNT int = 4
 const INFO_NORMAL_CASE string = "Info"
+const MERGE_LONG_DESCRIPTION = "Merge consecutive entries for the same project+task, default is today's entries, into a single entry. Their notes and tasks are combined."
+const MERGE_SHORT_DESCRIPTION = "Merge consecutive entries for the same project+task"
 const MAY_BE_OVERRIDDEN_BY_GLOBAL_CONFIGURATION_SETTING = "* May be overridden by global configuration setting"
 const NATURAL_LANGUAGE_DESCRIPTION string = "Natural Language Time, e.g., '18 minutes ago' or '9:45am'"
 const NOTE string = "note"
@@ -127,13 +189,17 @@ const PROJECTS_NORMAL_CASE = "Project(s)"
 const PUSHED = "pushed"
 const PUSHED_NORMAL_CASE string = "Pushed"
 const PUSH_API_KEY = "push.api_key"
-const PUSH_LONG_DESCRIPTION = "Push all uncommitted time data to remote server defined in .khronos.yaml"
+const PUSH_LONG_DESCRIPTION = "Push the entries with a ticket that have not been pushed yet, from any date, to the push targets defined in .khronos.yaml. Choose the entries from a checklist, narrowed down with --from, --to, and --ticket. With --dry-run, the exact JSON payloads and URLs are shown instead. A push target added later also gets the entries already pushed to the other targets; entries pushed before there were push targets, or only to targets since renamed or removed, are not pushed again."
 const PUSH_SHORT_DESCRIPTION = "Push all uncommitted time data to remote server defined in .khronos.yaml"
+const PUSH_TARGET = "push.target"
+const PUSH_TARGETS = "push.targets"
+const PUSH_TARGET_DEFAULT = "default"
 const PUSH_TYPE = "push.type"
 const PUSH_TYPE_JIRA = "jira"
 const PUSH_JIRA_V3_URL_TEMPLATE = "/rest/api/3/issue/%s/worklog"
 const PUSH_URL = "push.url"
 const PUSH_USERNAME = "push.username"
+const REMOTE_ID = "remote_id"
 const REPORT_BY_DAY = "report.by_day"
 const REPORT_BY_DAY_FORMAT string = "%-10s  %-38s  %-20s  %-20s"
 const REPORT_BY_ENTRY = "report.by_entry"
@@ -149,23 +215,46 @@ const REQUIRE_NOTE_WITH_ASTERISK string = "require note*"
 const ROOT_LONG_DESCRIPTION = "Khronos is a simple command line tool use to track the time you spend on a specific project and the one or more tasks associated with that project.\nIt was inspired by the concepts of utt (Ultimate Time Tracker) and timetrap."
 const ROOT_SHORT_DESCRIPTION = "Simple program used to track time spent on projects and tasks"
 const ROUND_TO_MINUTES string = "round_to_minutes"
+const RULES string = "rules"
+const RULES_LONG_DESCRIPTION = "List the rules in the configuration file, which classify entries by their project, task, note, weekday, and time of day as they are added, or apply them to existing entries."
+const RULES_SHORT_DESCRIPTION = "List the rules and apply them to existing entries"
 const SECONDS_PER_DAY = 86400
+const SHIFT_LONG_DESCRIPTION = "Shift a run of entries, default is all of today's entries, earlier or later in time while preserving their order."
+const SHIFT_SHORT_DESCRIPTION = "Shift entries earlier or later in time"
 const SHOW_LONG_DESCRIPTION = "Show various information."
 const SHOW_SHORT_DESCRIPTION = "Show various information"
 const SPACE_CHARACTER string = " "
+const SPLIT_LONG_DESCRIPTION = "Split an entry, default is the last entry, into multiple entries at the given points in time. Each resulting segment can be given its own project+task and note."
+const SPLIT_SHORT_DESCRIPTION = "Split an entry into multiple entries"
 const SPLIT_WORK_FROM_BREAK_TIME string = "split_work_from_break_time"
 const START_END_NORMAL_CASE = "Start-End"
 const STATISTICS string = "statistics"
+const STATUS_LONG_DESCRIPTION = "Show the configuration files in effect, the workspace rule matching the current directory or git repository, any commits pending from the post-commit hook, and the last entry."
+const STATUS_SHORT_DESCRIPTION = "Show the current workspace and last entry"
 const STRETCH_LONG_DESCRIPTION = "Stretch the latest entry to 'now' or whatever is specified using the 'at' flag command."
 const STRETCH_SHORT_DESCRIPTION = "Stretch the latest entry"
+const SUGGEST_LONG_DESCRIPTION = "Suggest entries for a day, default is today, from the commits you authored in local git repositories since the day's last entry. Review, edit, and accept the suggestions before they are added."
+const SUGGEST_SHORT_DESCRIPTION = "Suggest entries from your git commits"
+const TAG string = "tag"
+const TAG_NORMAL_CASE string = "Tag"
+const TAG_PREFIX string = "#"
 const TASK string = "task"
 const TASK_DELIMITER string = "+"
 const TASK_NORMAL_CASE = "Task"
 const TASKS_NORMAL_CASE = "Task(s)"
 const TICKET string = "ticket"
+const TICKET_BRANCH_PATTERN string = "ticket_branch_pattern"
+const TICKET_FROM_BRANCH string = "ticket_from_branch"
+const TICKET_FROM_NOTE string = "ticket_from_note"
+const TICKET_NOTE_PATTERN string = "ticket_note_pattern"
+const TICKET_NOTE_PROJECTS string = "ticket_note_projects"
 const TICKET_NORMAL_CASE string = "Ticket"
+const TIME_NORMAL_CASE = "Time"
 const TOTAL = "TOTAL"
+const TUI_LONG_DESCRIPTION = "Open a full-screen dashboard showing the day's timeline, its entries, and a by-project summary, with week and month totals. Entries can be added, amended, split, and deleted from the dashboard."
+const TUI_SHORT_DESCRIPTION = "Open the full-screen dashboard"
 const UNKNOWN_UID int64 = -1
+const UNTRACKED string = "***untracked"
 const UNPUSHED = "unpushed"
 const URL = "url"
 const URL_NORMAL_CASE = "URL"
@@ -175,3 +264,4 @@ const WEB_LONG_DESCRIPTION = "Open the Khronos website in your default browser."
 const WEB_SHORT_DESCRIPTION = "Open the Khronos website in your default browser"
 const WEB_SITE string = "https://github.com/jlanzarotta/khronos/"
 const WEEK_START string = "week_start"
+const WORKSPACES string = "workspaces"
@@ -154,51 +154,90 @@ func (db *Database) ConvertAllEntriesToUTC() {
 }
 
 func (db *Database) InsertNewEntry(entry models.Entry) {
+	db.InsertNewEntries([]models.Entry{entry})
+}
+
+// InsertNewEntries inserts all the given entries, along with their properties,
+// in a single transaction.  Either all the entries are written or none are.
+func (db *Database) InsertNewEntries(entries []models.Entry) {
+	db.ReplaceEntries([]int64{}, entries)
+}
+
+// ReplaceEntries deletes the entries with the given uids and inserts the given
+// new entries, along with their properties, in a single transaction.  Either
+// all the changes are written or none are.
+func (db *Database) ReplaceEntries(uids []int64, entries []models.Entry) {
 	tx, err := db.Conn.BeginTx(db.Context, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
 		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
 		os.Exit(1)
 	}
 
-	result, err := tx.ExecContext(db.Context, "INSERT INTO entry (uid, project, note, entry_datetime) VALUES (?, ?, ?, ?);", nil, entry.Project, entry.Note, entry.EntryDatetime)
-	if err != nil {
-		rollBackError := tx.Rollback()
-		if rollBackError != nil {
-			log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), rollBackError.Error())
-			os.Exit(1)
+	for _, uid := range uids {
+		err = deleteEntry(db, tx, uid)
+		if err != nil {
+			rollback(tx, err)
+		}
+	}
+
+	for _, entry := range entries {
+		err = insertEntry(db, tx, entry)
+		if err != nil {
+			rollback(tx, err)
 		}
+	}
 
+	err = tx.Commit()
+	if err != nil {
 		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
 		os.Exit(1)
 	}
+}
+
+// rollback rolls back the given transaction and exits, reporting the error that
+// caused the rollback.
+func rollback(tx *sql.Tx, err error) {
+	rollBackError := tx.Rollback()
+	if rollBackError != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), rollBackError.Error())
+		os.Exit(1)
+	}
+
+	log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+	os.Exit(1)
+}
+
+func insertEntry(db *Database, tx *sql.Tx, entry models.Entry) error {
+	result, err := tx.ExecContext(db.Context, "INSERT INTO entry (uid, project, note, entry_datetime) VALUES (?, ?, ?, ?);", nil, entry.Project, entry.Note, entry.EntryDatetime)
+	if err != nil {
+		return err
+	}
 
 	// Now that the record was inserted, get the last inserted id... in our case it it the UID.
 	uid, err := result.LastInsertId()
 	if err != nil {
-		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
-		os.Exit(1)
+		return err
 	}
 
 	// Now insert each of the properties for this entry.
 	for _, v := range entry.Properties {
 		_, err := tx.ExecContext(db.Context, "INSERT INTO property (entry_uid, name, value) VALUES (?, ?, ?);", uid, v.Name, v.Value)
 		if err != nil {
-			rollBackError := tx.Rollback()
-			if rollBackError != nil {
-				log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), rollBackError.Error())
-				os.Exit(1)
-			}
-
-			log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
-			os.Exit(1)
+			return err
 		}
 	}
 
-	err = tx.Commit()
+	return nil
+}
+
+func deleteEntry(db *Database, tx *sql.Tx, uid int64) error {
+	_, err := tx.ExecContext(db.Context, "DELETE FROM property WHERE entry_uid = ?;", uid)
 	if err != nil {
-		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
-		os.Exit(1)
+		return err
 	}
+
+	_, err = tx.ExecContext(db.Context, "DELETE FROM entry WHERE uid = ?;", uid)
+	return err
 }
 
 func (db *Database) GetDistinctUIDs(start carbon.Carbon, end carbon.Carbon, project string) []DistinctUID {
@@ -432,6 +471,90 @@ func (db *Database) GetLastEntry() models.Entry {
 	return entry
 }
 
+// GetEntryBefore returns the latest entry whose date/time is strictly before
+// the given ISO8601 date/time.  If there is no such entry, an entry with an
+// UNKNOWN_UID is returned.
+func (db *Database) GetEntryBefore(entryDatetime string) models.Entry {
+	result, err := db.Conn.QueryContext(db.Context, "SELECT e.uid FROM entry e WHERE e.entry_datetime < ? ORDER BY entry_datetime DESC LIMIT 1;", entryDatetime)
+	if err != nil {
+		log.Fatalf("%s: Error trying to retrieve prior Uid. %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	var priorUid int64
+	result.Next()
+	err = result.Scan(&priorUid)
+	result.Close()
+	if err != nil {
+		return models.NewEntry(constants.UNKNOWN_UID, constants.EMPTY, constants.EMPTY, constants.EMPTY)
+	}
+
+	// Create entry from the data from the database.
+	var entry models.Entry = db.GetEntry(priorUid)
+
+	return entry
+}
+
+// GetEntryAfter returns the earliest entry whose date/time is strictly after
+// the given ISO8601 date/time.  If there is no such entry, an entry with an
+// UNKNOWN_UID is returned.
+func (db *Database) GetEntryAfter(entryDatetime string) models.Entry {
+	result, err := db.Conn.QueryContext(db.Context, "SELECT e.uid FROM entry e WHERE e.entry_datetime > ? ORDER BY entry_datetime LIMIT 1;", entryDatetime)
+	if err != nil {
+		log.Fatalf("%s: Error trying to retrieve next Uid. %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	var nextUid int64
+	result.Next()
+	err = result.Scan(&nextUid)
+	result.Close()
+	if err != nil {
+		return models.NewEntry(constants.UNKNOWN_UID, constants.EMPTY, constants.EMPTY, constants.EMPTY)
+	}
+
+	// Create entry from the data from the database.
+	var entry models.Entry = db.GetEntry(nextUid)
+
+	return entry
+}
+
+// GetProjectTaskUses returns one record per project+task used by the entries
+// between the given start and end date/times, along with the entry's ticket,
+// if any, ordered by date/time.
+func (db *Database) GetProjectTaskUses(start carbon.Carbon, end carbon.Carbon) []ProjectTaskUse {
+	results, err := db.Conn.QueryContext(db.Context, `
+		SELECT
+			e.project, p.value, e.entry_datetime,
+			COALESCE((SELECT t.value FROM property t WHERE t.entry_uid = e.uid AND t.name = ? LIMIT 1), '')
+		FROM entry e
+		JOIN property p ON p.entry_uid = e.uid
+		WHERE (e.entry_datetime BETWEEN ? AND ?) AND p.name = ?
+		ORDER BY e.entry_datetime;
+		`, constants.TICKET, start.ToIso8601String(), end.ToIso8601String(), constants.TASK,
+	)
+
+	if err != nil {
+		log.Fatalf("%s: Error trying to retrieve project+task uses. %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+	defer results.Close()
+
+	records := []ProjectTaskUse{}
+	for results.Next() {
+		var use ProjectTaskUse
+		err = results.Scan(&use.Project, &use.Task, &use.EntryDatetime, &use.Ticket)
+		if err != nil {
+			log.Fatalf("%s: Error trying to scan results into ProjectTaskUse data structure. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		records = append(records, use)
+	}
+
+	return records
+}
+
 func (db *Database) GetCountEntries() int64 {
 	result, err := db.Conn.QueryContext(db.Context, "SELECT COUNT(*) FROM entry;")
 	if err != nil {
@@ -485,6 +608,45 @@ func (db *Database) GetUnpushedEntries() []models.Entry {
 	return entries
 }
 
+// GetEntriesPushedToTargets returns the entries already pushed that record
+// which push targets they were pushed to, e.g., in a pushed.<target> property,
+// so a push target added since can still be pushed to.
+func (db *Database) GetEntriesPushedToTargets() []models.Entry {
+	results, err := db.Conn.Query("SELECT DISTINCT e.uid, e.project, e.note, e.entry_datetime FROM entry e JOIN property p on p.entry_uid = e.uid WHERE p.name LIKE ?",
+		constants.PUSHED+".%")
+	if err != nil {
+		log.Fatalf("%s: Error trying to retrieve Entry records. %s.\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	records := []Entry{}
+	for results.Next() {
+		var entry Entry
+		err = results.Scan(&entry.Uid, &entry.Project, &entry.Note, &entry.EntryDatetime)
+		if err != nil {
+			log.Fatalf("%s: Error trying to Scan Entries results into data structure. %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+			os.Exit(1)
+		}
+
+		records = append(records, entry)
+	}
+
+	var entries = []models.Entry{}
+	for _, e := range records {
+		var entry models.Entry = models.NewEntry(e.Uid, e.Project, e.Note.String, e.EntryDatetime)
+		var properties []Property = db.GetProperties(entry.Uid)
+		for _, p := range properties {
+			entry.AddEntryProperty(p.Name.String, p.Value.String)
+		}
+
+		if entry.GetPushedAsString() != constants.EMPTY {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
 func CreateArchiveFile(entryWithProperty []EntryWithProperty, compress bool) {
 	// Create our unique archive file.
 	var filename = constants.APPLICATION_NAME_LOWERCASE + "_archive_" + carbon.Now(carbon.Local).ToShortDateTimeString()
@@ -728,13 +890,15 @@ func (db *Database) NukeAllEntries(dryRun bool, archive bool, compress bool) int
 func (db *Database) UpdateEntry(entry models.Entry) {
 	var previous bool = false
 	var query strings.Builder
+	var args []any
 
 	// Update the Entry.
 	query.WriteString("UPDATE entry")
 	query.WriteString(" SET")
 
 	if entry.Project != constants.EMPTY {
-		query.WriteString(fmt.Sprintf(" project = '%s'", entry.Project))
+		query.WriteString(" project = ?")
+		args = append(args, entry.Project)
 		previous = true
 	}
 
@@ -742,7 +906,8 @@ func (db *Database) UpdateEntry(entry models.Entry) {
 		if previous {
 			query.WriteString(", ")
 		}
-		query.WriteString(fmt.Sprintf(" note = '%s'", entry.Note))
+		query.WriteString(" note = ?")
+		args = append(args, entry.Note)
 		previous = true
 	}
 
@@ -750,17 +915,19 @@ func (db *Database) UpdateEntry(entry models.Entry) {
 		if previous {
 			query.WriteString(", ")
 		}
-		query.WriteString(fmt.Sprintf(" entry_datetime = '%s'", entry.EntryDatetime))
+		query.WriteString(" entry_datetime = ?")
+		args = append(args, entry.EntryDatetime)
 	}
 
-	query.WriteString(fmt.Sprintf(" WHERE uid = %d;", entry.Uid))
+	query.WriteString(" WHERE uid = ?;")
+	args = append(args, entry.Uid)
 
 	if viper.GetBool(constants.DEBUG) {
-		log.Printf("Query[%s]\n", query.String())
+		log.Printf("Query[%s] Args%v\n", query.String(), args)
 	}
 
 	// Execute the update.
-	_, err := db.Conn.ExecContext(db.Context, query.String())
+	_, err := db.Conn.ExecContext(db.Context, query.String(), args...)
 	if err != nil {
 		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
 		os.Exit(1)
@@ -772,15 +939,15 @@ func (db *Database) UpdateEntry(entry models.Entry) {
 		query.Reset()
 		query.WriteString("UPDATE property")
 		query.WriteString(" SET")
-		query.WriteString(fmt.Sprintf(" value = '%s'", task))
-		query.WriteString(fmt.Sprintf(" WHERE entry_uid = %d and name = '%s';", entry.Uid, constants.TASK))
+		query.WriteString(" value = ?")
+		query.WriteString(" WHERE entry_uid = ? and name = ?;")
 
 		if viper.GetBool(constants.DEBUG) {
-			log.Printf("Query[%s]\n", query.String())
+			log.Printf("Query[%s] Args[%s %d %s]\n", query.String(), task, entry.Uid, constants.TASK)
 		}
 
 		// Execute the update.
-		_, err = db.Conn.ExecContext(db.Context, query.String())
+		_, err = db.Conn.ExecContext(db.Context, query.String(), task, entry.Uid, constants.TASK)
 		if err != nil {
 			log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
 			os.Exit(1)
@@ -793,15 +960,15 @@ func (db *Database) UpdateEntry(entry models.Entry) {
 		query.Reset()
 		query.WriteString("UPDATE property")
 		query.WriteString(" SET")
-		query.WriteString(fmt.Sprintf(" value = '%s'", ticket))
-		query.WriteString(fmt.Sprintf(" WHERE entry_uid = %d and name = '%s';", entry.Uid, constants.TICKET))
+		query.WriteString(" value = ?")
+		query.WriteString(" WHERE entry_uid = ? and name = ?;")
 
 		if viper.GetBool(constants.DEBUG) {
-			log.Printf("Query[%s]\n", query.String())
+			log.Printf("Query[%s] Args[%s %d %s]\n", query.String(), ticket, entry.Uid, constants.TICKET)
 		}
 
 		// Execute the update.
-		_, err = db.Conn.ExecContext(db.Context, query.String())
+		_, err = db.Conn.ExecContext(db.Context, query.String(), ticket, entry.Uid, constants.TICKET)
 		if err != nil {
 			log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
 			os.Exit(1)
@@ -809,24 +976,138 @@ func (db *Database) UpdateEntry(entry models.Entry) {
 	}
 }
 
-func (db *Database) UpdateEntryPushed(entryUid int64) {
-	var query strings.Builder
-	var now carbon.Carbon = *carbon.Now()
+// UpdateEntriesDatetime writes the date/time of each of the given entries in a
+// single transaction.  Either all the entries are updated or none are.
+func (db *Database) UpdateEntriesDatetime(entries []models.Entry) {
+	tx, err := db.Conn.BeginTx(db.Context, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
 
-	// Update the Entry's pushed property.
-	query.WriteString("UPDATE property")
-	query.WriteString(" SET")
-	query.WriteString(fmt.Sprintf(" value = '%s'", now.ToIso8601String(carbon.UTC)))
-	query.WriteString(fmt.Sprintf(" WHERE entry_uid = %d and name = '%s';", entryUid, constants.PUSHED))
+	for _, entry := range entries {
+		_, err = tx.ExecContext(db.Context, "UPDATE entry SET entry_datetime = ? WHERE uid = ?;", entry.EntryDatetime, entry.Uid)
+		if err != nil {
+			rollback(tx, err)
+		}
+	}
 
-	if viper.GetBool(constants.DEBUG) {
-		log.Printf("Query[%s]\n", query.String())
+	err = tx.Commit()
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
 	}
+}
 
-	// Execute the update.
-	_, err := db.Conn.ExecContext(db.Context, query.String())
+// UpdateEntries writes the project, note, task(s), ticket, and pushed state of
+// each of the given entries in a single transaction.  Either all the entries
+// are updated or none are.  The other properties, e.g., tags, are left as they
+// are.
+func (db *Database) UpdateEntries(entries []models.Entry) {
+	tx, err := db.Conn.BeginTx(db.Context, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	for _, entry := range entries {
+		_, err = tx.ExecContext(db.Context, "UPDATE entry SET project = ?, note = ? WHERE uid = ?;", entry.Project, entry.Note, entry.Uid)
+		if err != nil {
+			rollback(tx, err)
+		}
+
+		// Replace the task(s), ticket, and pushed state with the entry's.
+		for _, name := range []string{constants.TASK, constants.TICKET, constants.PUSHED} {
+			_, err = tx.ExecContext(db.Context, "DELETE FROM property WHERE entry_uid = ? AND name = ?;", entry.Uid, name)
+			if err != nil {
+				rollback(tx, err)
+			}
+
+			for _, p := range entry.Properties {
+				if p.Name == name {
+					_, err = tx.ExecContext(db.Context, "INSERT INTO property (entry_uid, name, value) VALUES (?, ?, ?);", entry.Uid, p.Name, p.Value)
+					if err != nil {
+						rollback(tx, err)
+					}
+				}
+			}
+		}
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
 		os.Exit(1)
 	}
 }
+
+// UpdateEntriesProperties replaces all the properties of each of the given
+// entries, e.g., their tags, with the entry's, in a single transaction.
+// Either all the entries are updated or none are.
+func (db *Database) UpdateEntriesProperties(entries []models.Entry) {
+	tx, err := db.Conn.BeginTx(db.Context, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	for _, entry := range entries {
+		_, err = tx.ExecContext(db.Context, "DELETE FROM property WHERE entry_uid = ?;", entry.Uid)
+		if err != nil {
+			rollback(tx, err)
+		}
+
+		for _, p := range entry.Properties {
+			_, err = tx.ExecContext(db.Context, "INSERT INTO property (entry_uid, name, value) VALUES (?, ?, ?);", entry.Uid, p.Name, p.Value)
+			if err != nil {
+				rollback(tx, err)
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+}
+
+// UpdateEntryPushedTo records that the entry was pushed to the push target,
+// along with the ID the target gave it, in a single transaction.  If the
+// entry has now been pushed to every target it is pushed to, its pushed
+// property is updated too.
+func (db *Database) UpdateEntryPushedTo(entryUid int64, target string, remoteID string, pushed bool) {
+	var now string = carbon.Now().ToIso8601String(carbon.UTC)
+
+	tx, err := db.Conn.BeginTx(db.Context, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+
+	for _, p := range [][]string{{models.PushedToProperty(target), now}, {models.RemoteIDProperty(target), remoteID}} {
+		_, err = tx.ExecContext(db.Context, "DELETE FROM property WHERE entry_uid = ? AND name = ?;", entryUid, p[0])
+		if err != nil {
+			rollback(tx, err)
+		}
+
+		_, err = tx.ExecContext(db.Context, "INSERT INTO property (entry_uid, name, value) VALUES (?, ?, ?);", entryUid, p[0], p[1])
+		if err != nil {
+			rollback(tx, err)
+		}
+	}
+
+	if pushed {
+		_, err = tx.ExecContext(db.Context, "UPDATE property SET value = ? WHERE entry_uid = ? AND name = ?;", now, entryUid, constants.PUSHED)
+		if err != nil {
+			rollback(tx, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Fatalf("%s: %s\n", color.RedString(constants.FATAL_NORMAL_CASE), err.Error())
+		os.Exit(1)
+	}
+}
+
@@ -28,39 +28,11 @@ CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
 ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
 POSSIBILITY OF SUCH DAMAGE.
 */
-package cmd
+package database
 
-import (
-	"khronos/constants"
-	"log"
-	"os"
-
-	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-)
-
-var configureCmd = &cobra.Command{
-	Use:     "configure",
-	Aliases: []string{"c", "config", "conf"},
-	Short:   constants.CONFIGURE_SHORT_DESCRIPTION,
-	Long:    constants.CONFIGURE_LONG_DESCRIPTION,
-	Run: func(cmd *cobra.Command, args []string) {
-		runConfigure(args)
-	},
-}
-
-func init() {
-	rootCmd.AddCommand(configureCmd)
-}
-
-func runConfigure(args []string) {
-	if len(args) != 0 {
-		log.Printf("usage: khronos configure")
-		os.Exit(1)
-	}
-
-	log.Printf("Config file is at \"%s\"\n", viper.GetViper().ConfigFileUsed())
-	// TODO: does configuration command do anything else?
-
-	os.Exit(0)
+type ProjectTaskUse struct {
+	Project       string
+	Task          string
+	EntryDatetime string
+	Ticket        string
 }
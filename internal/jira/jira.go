@@ -36,17 +36,17 @@ import (
 	"fmt"
 	"khronos/constants"
 	"khronos/internal/models"
+	"khronos/internal/push"
+	"khronos/internal/rest"
 	"khronos/internal/util"
-	"log"
-	"os"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/agrison/go-commons-lang/stringUtils"
-	"github.com/fatih/color"
 )
 
 var JiraPushUrl string
-var JiraLogWorkToTicketUrl string
 var JiraBrowseTicketUrl string
 
 //  {
@@ -95,12 +95,6 @@ func FormatJiraUrl(url string, ticket string) string {
 	}
 }
 
-type JiraRequest struct {
-	EntryUid int64
-	Ticket   string
-	Payload  []byte
-}
-
 // JIRATimeLayout is the layout Jira expects.
 // Note the three‑digit millisecond part (".000") and the offset without a colon.
 const JIRATimeLayout = "2006-01-02T15:04:05.000-0700"
@@ -124,57 +118,98 @@ func UTCToJira(utcStr string) (string, error) {
 	return utc.Format(JIRATimeLayout), nil
 }
 
-func JiraNewRequests(roundToMinutes int64, entries []models.Entry) (result []JiraRequest) {
-	var requests []JiraRequest
-
-	for _, entry := range entries {
-		var ticket string = entry.GetTicketAsString()
-		var pushed string = entry.GetPushedAsString()
-
-		// Only look at records that have a ticket associated with them and the
-		// pushed string is empty.
-		if !stringUtils.IsBlank(ticket) && stringUtils.IsBlank(pushed) {
-			var request JiraRequest
-			request.EntryUid = entry.Uid
-			request.Ticket = entry.GetTicketAsString()
-			jiraTime, err := UTCToJira(entry.EntryDatetime)
-			if err != nil {
-				log.Fatalf("%s: Failed to convert %s to Jira time.\n", color.RedString(constants.FATAL_NORMAL_CASE),
-					entry.EntryDatetime)
-				os.Exit(1)
-			}
-
-			// Fill a fresh Payload struct.
-			p := Payload{
-				Started:          jiraTime,
-				TimeSpentSeconds: util.Round(roundToMinutes, entry.Duration),
-				Comment: Comment{
-					Type:    "doc",
-					Version: 1,
-					Content: []Block{
+func init() {
+	push.Register(constants.PUSH_TYPE_JIRA, NewPusher)
+}
+
+// Pusher logs the work of entries to their Jira tickets.
+type Pusher struct {
+	target      push.Target
+	credentials models.Credentials
+}
+
+// NewPusher creates the Pusher for a Jira target, which needs its URL and
+// credentials.
+func NewPusher(target push.Target) (push.Pusher, error) {
+	for _, setting := range [][]string{{"url", target.URL}, {"username", target.Username}, {"api_key", target.APIKey}} {
+		if stringUtils.IsBlank(setting[1]) {
+			return nil, fmt.Errorf("push target[%s] is missing its %s", target.Name, setting[0])
+		}
+	}
+
+	return &Pusher{
+		target:      target,
+		credentials: models.Credentials{Username: target.Username, Password: target.APIKey},
+	}, nil
+}
+
+// NewRequest builds the worklog for the entry's ticket.
+func (p *Pusher) NewRequest(entry models.Entry, roundToMinutes int64) (push.Request, error) {
+	var ticket string = entry.GetTicketAsString()
+	if stringUtils.IsBlank(ticket) {
+		return push.Request{}, fmt.Errorf("the entry has no ticket")
+	}
+
+	jiraTime, err := UTCToJira(entry.EntryDatetime)
+	if err != nil {
+		return push.Request{}, fmt.Errorf("failed to convert %s to Jira time", entry.EntryDatetime)
+	}
+
+	// Fill a fresh Payload struct.
+	payload := Payload{
+		Started:          jiraTime,
+		TimeSpentSeconds: util.Round(roundToMinutes, entry.Duration),
+		Comment: Comment{
+			Type:    "doc",
+			Version: 1,
+			Content: []Block{
+				{
+					Type: "paragraph",
+					Content: []TextNode{
 						{
-							Type: "paragraph",
-							Content: []TextNode{
-								{
-									Type: "text",
-									Text: entry.Note,
-								},
-							},
+							Type: "text",
+							Text: entry.Note,
 						},
 					},
 				},
-			}
+			},
+		},
+	}
 
-			bytes, err := json.Marshal(p)
-			if err != nil {
-				log.Fatalf("Failed to marshal payload %v", err)
-				os.Exit(1)
-			}
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return push.Request{}, fmt.Errorf("failed to marshal payload %v", err)
+	}
 
-			request.Payload = bytes
-			requests = append(requests, request)
-		}
+	return push.Request{
+		EntryUid: entry.Uid,
+		Ticket:   ticket,
+		Method:   http.MethodPost,
+		URL:      FormatJiraUrl(strings.TrimSuffix(p.target.URL, "/")+constants.PUSH_JIRA_V3_URL_TEMPLATE, ticket),
+		Payload:  bytes,
+	}, nil
+}
+
+// Send posts the worklog with basic authentication.
+func (p *Pusher) Send(request push.Request) (push.Response, error) {
+	return push.SendHTTP(request, map[string]string{
+		"Authorization": fmt.Sprintf("Basic %v", rest.BasicAuth(&p.credentials)),
+		"Content-Type":  "application/json",
+	})
+}
+
+// RemoteID returns the ID of the worklog Jira created.
+func (p *Pusher) RemoteID(response push.Response) (string, error) {
+	if response.StatusCode != http.StatusCreated {
+		return constants.EMPTY, fmt.Errorf("Jira Server responded: %v\n{%q}", response.Status, response.Body)
+	}
+
+	var worklog struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(response.Body, &worklog); err != nil {
+		return constants.EMPTY, fmt.Errorf("Jira Server responded with an unreadable worklog: %v", err)
 	}
 
-	return requests
+	return worklog.ID, nil
 }
@@ -34,6 +34,7 @@ package models
 import (
 	"khronos/constants"
 	"strings"
+	"time"
 
 	"github.com/agrison/go-commons-lang/stringUtils"
 	"github.com/dromara/carbon/v2"
@@ -116,6 +117,22 @@ func (e *Entry) GetTasksAsString() string {
 	return result
 }
 
+func (e *Entry) GetTagsAsString() string {
+	var result string
+
+	for _, element := range e.Properties {
+		if strings.EqualFold(element.Name, constants.TAG) {
+			if len(result) > 0 {
+				result += ", "
+			}
+
+			result += element.Value
+		}
+	}
+
+	return result
+}
+
 func (e *Entry) GetTicketAsString() string {
 	var result string
 
@@ -142,59 +159,125 @@ func (e *Entry) GetPushedAsString() string {
 	return result
 }
 
-func (e *Entry) Dump(vertical bool, indent_amount int) string {
+// GetPushedToAsString returns when the entry was pushed to the push target, if
+// it was.
+func (e *Entry) GetPushedToAsString(target string) string {
 	var result string
 
-	// Add the break or project.
-	if vertical {
-		result = "\n"
+	for _, element := range e.Properties {
+		if strings.EqualFold(element.Name, PushedToProperty(target)) {
+			result = element.Value
+			break
+		}
 	}
 
-	if strings.EqualFold(e.Project, constants.BREAK) {
-		result = result + strings.Repeat(constants.SPACE_CHARACTER, indent_amount) + color.YellowString("Break Time")
-	} else {
-		result = result + strings.Repeat(constants.SPACE_CHARACTER, indent_amount) + color.YellowString("Project") + "[" + e.Project + "]"
+	return result
+}
+
+// IsPushed reports whether the entry was pushed anywhere: to the push target,
+// or to any one of the push targets.
+func (e *Entry) IsPushed() bool {
+	for _, element := range e.Properties {
+		if IsPushProperty(element.Name) && !stringUtils.IsBlank(element.Value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PushedToProperty returns the name of the property recording when an entry
+// was pushed to the push target, e.g., pushed.acme.
+func PushedToProperty(target string) string {
+	return constants.PUSHED + "." + target
+}
+
+// RemoteIDProperty returns the name of the property recording the ID the push
+// target gave a pushed entry, e.g., remote_id.acme.
+func RemoteIDProperty(target string) string {
+	return constants.REMOTE_ID + "." + target
+}
+
+// IsPushProperty reports whether the property records an entry's push state:
+// pushed, or the pushed.<target> and remote_id.<target> of a push target.
+func IsPushProperty(name string) bool {
+	return strings.EqualFold(name, constants.PUSHED) || strings.HasPrefix(name, constants.PUSHED+".") ||
+		strings.HasPrefix(name, constants.REMOTE_ID+".")
+}
+
+// dumpLabelWidth is the width the labels of a vertical Dump are right aligned
+// to, that of its longest label.
+const dumpLabelWidth = len("Duration")
+
+func (e *Entry) Dump(vertical bool, indent_amount int) string {
+	var indent string = strings.Repeat(constants.SPACE_CHARACTER, indent_amount)
 
-		// Add the task(s).
+	// field returns a label and its value; when vertical, on a line of its
+	// own with the label right aligned.
+	var field = func(label string, value string) string {
 		if vertical {
-			result = result + "\n  "
+			return "\n" + indent + color.YellowString("%*s", dumpLabelWidth, label) + "[" + value + "]"
 		}
-		result = result + strings.Repeat(constants.SPACE_CHARACTER, indent_amount) + color.YellowString(" Task") + "[" + e.GetTasksAsString() + "]"
+
+		return " " + color.YellowString(label) + "[" + value + "]"
 	}
 
-	// Add the note if there is one.
-	if len(e.Note) > 0 {
+	var result string
+
+	// Add the break or project, and the task(s).
+	if strings.EqualFold(e.Project, constants.BREAK) || strings.EqualFold(e.Project, constants.UNTRACKED) {
+		var label string = "Break Time"
+		if strings.EqualFold(e.Project, constants.UNTRACKED) {
+			label = "Untracked Time"
+		}
+
 		if vertical {
-			result += "\n  "
+			result = "\n" + indent
 		}
-		result += strings.Repeat(constants.SPACE_CHARACTER, indent_amount) + color.YellowString(" Note") + "[" + e.Note + "]"
+		result += color.YellowString(label)
+	} else {
+		result = field("Project", e.Project) + field("Task", e.GetTasksAsString())
+	}
+
+	// Add the note if there is one.
+	if len(e.Note) > 0 {
+		result += field("Note", e.Note)
 	}
 
 	// Add the TICKET if there is one.
 	var ticket = e.GetTicketAsString()
 	if !stringUtils.IsBlank(ticket) {
-		if vertical {
-			result += "\n"
-		}
+		result += field("Ticket", ticket)
+	}
 
-		result += strings.Repeat(constants.SPACE_CHARACTER, indent_amount) + color.YellowString(" Ticket") + "[" + ticket + "]"
+	// Add the TAGs if there are any.
+	var tags = e.GetTagsAsString()
+	if !stringUtils.IsBlank(tags) {
+		result += field("Tags", tags)
 	}
 
 	// Add the PUSHED if there is one.
 	var pushed = e.GetPushedAsString()
 	if !stringUtils.IsBlank(pushed) {
-		if vertical {
-			result += "\n"
-		}
+		result += field("Pushed", pushed)
+	}
+
+	// If a duration is known, show the start, end, and duration.  Otherwise,
+	// simply add the Date.
+	if e.Duration > 0 {
+		var end carbon.Carbon = *carbon.Parse(e.EntryDatetime)
+		var start carbon.Carbon = *end.Copy().SubSeconds(int(e.Duration))
 
-		result += strings.Repeat(constants.SPACE_CHARACTER, indent_amount) + color.YellowString(" Pushed") + "[" + pushed + "]"
+		result += field("Start", start.ToIso8601String(carbon.Local))
+		result += field("End", end.ToIso8601String(carbon.Local))
+		result += field("Duration", (time.Duration(e.Duration) * time.Second).String())
+	} else {
+		result += field("Date", carbon.Parse(e.EntryDatetime).ToIso8601String(carbon.Local))
 	}
 
-	// Add the Date.
-	if vertical {
-		result += "\n  "
+	if !vertical {
+		result = strings.TrimPrefix(result, " ")
 	}
-	result += strings.Repeat(constants.SPACE_CHARACTER, indent_amount) + color.YellowString(" Date") + "[" + carbon.Parse(e.EntryDatetime).ToIso8601String(carbon.Local) + "]"
 
 	return result
 }
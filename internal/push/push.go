@@ -0,0 +1,151 @@
+/*
+Copyright © 2018-2026 Jeff Lanzarotta
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+ 1. Redistributions of source code must retain the above copyright notice,
+    this list of conditions and the following disclaimer.
+
+ 2. Redistributions in binary form must reproduce the above copyright notice,
+    this list of conditions and the following disclaimer in the documentation
+    and/or other materials provided with the distribution.
+
+ 3. Neither the name of the copyright holder nor the names of its contributors
+    may be used to endorse or promote products derived from this software
+    without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+package push
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"khronos/internal/models"
+	"khronos/internal/rest"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Target is a server entries are pushed to, with its own credentials.  Only
+// the entries whose ticket matches the ticket pattern, if it has one, are
+// pushed to it.
+type Target struct {
+	Name          string `yaml:"name" mapstructure:"name"`
+	Type          string `yaml:"type" mapstructure:"type"`
+	URL           string `yaml:"url" mapstructure:"url"`
+	Username      string `yaml:"username" mapstructure:"username"`
+	APIKey        string `yaml:"api_key" mapstructure:"api_key"`
+	TicketPattern string `yaml:"ticket_pattern" mapstructure:"ticket_pattern"`
+}
+
+// Request is an entry ready to be pushed to a target.
+type Request struct {
+	EntryUid int64
+	Ticket   string
+	Method   string
+	URL      string
+	Payload  []byte
+}
+
+// Response is what the target responded to a request.
+type Response struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+// Pusher pushes entries to a type of server, e.g., Jira.
+type Pusher interface {
+	// NewRequest builds the request pushing the entry, with its duration
+	// rounded to the given number of minutes.
+	NewRequest(entry models.Entry, roundToMinutes int64) (Request, error)
+
+	// Send sends the request to the target.
+	Send(request Request) (Response, error)
+
+	// RemoteID interprets the target's response, returning the ID the target
+	// gave the pushed entry, or an error if the entry was not pushed.
+	RemoteID(response Response) (string, error)
+}
+
+// Factory creates the Pusher for a target, or returns an error if the target
+// is missing something, e.g., its credentials.
+type Factory func(target Target) (Pusher, error)
+
+// registry holds the Factory of each type of server, by push.type.
+var registry = map[string]Factory{}
+
+// Register makes a type of server available to push to.  It is meant to be
+// called from the init function of the package implementing the Pusher.
+func Register(pushType string, factory Factory) {
+	registry[strings.ToLower(pushType)] = factory
+}
+
+// Types returns the types of server registered, in order.
+func Types() []string {
+	var types []string
+	for pushType := range registry {
+		types = append(types, pushType)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// IsType reports whether a type of server is registered.
+func IsType(pushType string) bool {
+	_, found := registry[strings.ToLower(pushType)]
+	return found
+}
+
+// New creates the Pusher for the target's type of server.
+func New(target Target) (Pusher, error) {
+	factory, found := registry[strings.ToLower(target.Type)]
+	if !found {
+		return nil, fmt.Errorf("push target[%s] has an unknown type[%s], must be %s", target.Name, target.Type, strings.Join(Types(), " or "))
+	}
+
+	return factory(target)
+}
+
+// SendHTTP sends the request with the given headers, e.g., its
+// Authorization, using rest.HTTPClient.  Any status is a response; only
+// failing to reach the target is an error.
+func SendHTTP(request Request, headers map[string]string) (Response, error) {
+	httpRequest, err := http.NewRequest(request.Method, request.URL, bytes.NewBuffer(request.Payload))
+	if err != nil {
+		return Response{}, err
+	}
+
+	for name, value := range headers {
+		httpRequest.Header.Add(name, value)
+	}
+
+	result, err := rest.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return Response{}, err
+	}
+	defer result.Body.Close()
+
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return Response{StatusCode: result.StatusCode, Status: result.Status, Body: body}, nil
+}
@@ -33,16 +33,9 @@ package rest
 
 import (
 	"encoding/base64"
-	"khronos/constants"
 	"khronos/internal/models"
-	"log"
 	"net/http"
-	"os"
 	"time"
-
-	"github.com/agrison/go-commons-lang/stringUtils"
-	"github.com/fatih/color"
-	"github.com/spf13/viper"
 )
 
 // HTTPClient is a default HTTP client, a proxy over http.Client.
@@ -57,26 +50,6 @@ func init() {
 	HTTPClient = &http.Client{Timeout: time.Second * 30}
 }
 
-func ReadCredentials() models.Credentials {
-	var username string = viper.GetString(constants.PUSH_USERNAME)
-	if stringUtils.IsEmpty(username) {
-		log.Fatalf("%s: Missing push username.  Please correct your configuration.\n", color.RedString(constants.FATAL_NORMAL_CASE))
-		os.Exit(1)
-	}
-
-	var apiKey string = viper.GetString(constants.PUSH_API_KEY)
-	if stringUtils.IsEmpty(apiKey) {
-		log.Fatalf("%s: Missing push api_key.  Please correct your configuration.\n", color.RedString(constants.FATAL_NORMAL_CASE))
-		os.Exit(1)
-	}
-
-	var credentials models.Credentials
-	credentials.Username = username
-	credentials.Password = apiKey
-
-	return credentials
-}
-
 func BasicAuth(cred *models.Credentials) string {
 	auth := cred.Username + ":" + cred.Password
 	return base64.StdEncoding.EncodeToString([]byte(auth))